@@ -4,8 +4,10 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/ramalabeysekera/cognito-user-management/config"
 	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
@@ -13,65 +15,55 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// membershipPair is a single user → group assignment
+type membershipPair struct {
+	user  string
+	group string
+}
+
 // addtogroupCmd represents the addtogroup command
 var addtogroupCmd = &cobra.Command{
 	Use:   "addtogroups",
-	Short: "Add a user to one or more groups in a Cognito User Pool",
-	Long: `The "addtogroups" command allows you to select a user from a Cognito User Pool 
+	Short: "Add one or more users to one or more groups in a Cognito User Pool",
+	Long: `The "addtogroups" command allows you to select users from a Cognito User Pool 
 and add them to one or more groups interactively. This command simplifies group 
-management by providing an intuitive CLI interface for selecting users and groups.`,
+management by providing an intuitive CLI interface for selecting users and groups.
+
+Every selected user is added to every selected group.
+
+Run this command with "--file=<path>" to read username,group pairs from a CSV file instead
+Use "--file=-" to read the pairs from stdin
+
+A summary of added, already-member and failed pairs is printed at the end.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+
 		// Get selected user pool from available pools
-		userPools, err := common.GetAllPools(config.AwsConfig)
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
 		if err != nil {
 			log.Println("Error fetching user pools:", err)
 			return
 		}
 		userPool := helpers.CallSingleSelect(userPools)
 		if userPool != "" {
-			users, err := common.GetUsersFromPool(userPool, config.AwsConfig)
-			if err != nil {
-				log.Println("Error fetching users:", err)
-				return
-			}
-			if len(users) == 0 {
-				log.Println("No users found in the selected user pool.")
-				return
-			}
-			// Let user select a user
-			// Use the interactive selection function to let the user choose a user
-			// This function will display the list of users and allow the user to select one
-			user := helpers.CallSingleSelect(users)
-
-			groups, err := common.GetGroupsFromPool(userPool, config.AwsConfig)
-			if err != nil {
-				log.Println("Error fetching groups:", err)
-				return
-			}
-			if len(groups) == 0 {
-				log.Println("No groups found in the selected user pool.")
-				return
-			}
-			fmt.Println("Select groups to add the user to:")
-			// Let user select a group
-			selectedGroups := helpers.CallMultiSelect(groups)
-			if err != nil {
-				log.Println("Error selecting group:", err)
-				return
-			}
-
-			if len(selectedGroups) == 0 {
-				log.Println("No groups selected.")
-				return
-			}
-			for _, group := range selectedGroups {
-				err = common.AddUserToGroup(userPool, user, group, config.AwsConfig)
+			var pairs []membershipPair
+			if file != "" {
+				pairs, err = readMembershipPairs(file)
 				if err != nil {
-					log.Println("Error adding user to group:", err)
+					helpers.PrintFatalErrorLog(err.Error())
+				}
+				if len(pairs) == 0 {
+					log.Println("No username,group pairs found in the file.")
+					return
+				}
+			} else {
+				pairs = selectMembershipPairs(cmd.Context(), userPool)
+				if len(pairs) == 0 {
 					return
 				}
-				helpers.PrintSuccessLog(fmt.Sprintf("User %s added to group %s\n", user, group))
 			}
+
+			addMembershipPairs(cmd.Context(), userPool, pairs)
 		} else {
 			helpers.PrintFatalErrorLog("No user pool selected")
 		}
@@ -81,4 +73,116 @@ management by providing an intuitive CLI interface for selecting users and group
 
 func init() {
 	rootCmd.AddCommand(addtogroupCmd)
+	addtogroupCmd.Flags().String("file", "", `Read username,group pairs from a CSV file, "-" for stdin`)
+}
+
+// selectMembershipPairs lets the user select users and groups and pairs every user with every group
+func selectMembershipPairs(ctx context.Context, userPool string) []membershipPair {
+	users, err := common.GetUsersFromPool(userPool, config.AwsConfig, ctx)
+	if err != nil {
+		log.Println("Error fetching users:", err)
+		return nil
+	}
+	if len(users) == 0 {
+		log.Println("No users found in the selected user pool.")
+		return nil
+	}
+	fmt.Println("Select users to add to groups:")
+	// Let user select the users
+	selectedUsers := helpers.CallMultiSelect(users)
+	if len(selectedUsers) == 0 {
+		log.Println("No users selected.")
+		return nil
+	}
+
+	groups, err := common.GetGroupsFromPool(userPool, config.AwsConfig, ctx)
+	if err != nil {
+		log.Println("Error fetching groups:", err)
+		return nil
+	}
+	if len(groups) == 0 {
+		log.Println("No groups found in the selected user pool.")
+		return nil
+	}
+	fmt.Println("Select groups to add the users to:")
+	// Let user select the groups
+	selectedGroups := helpers.CallMultiSelect(groups)
+	if len(selectedGroups) == 0 {
+		log.Println("No groups selected.")
+		return nil
+	}
+
+	var pairs []membershipPair
+	for _, user := range selectedUsers {
+		for _, group := range selectedGroups {
+			pairs = append(pairs, membershipPair{user: user, group: group})
+		}
+	}
+	return pairs
+}
+
+// readMembershipPairs reads username,group rows from a CSV file or stdin
+// A header row starting with "username" is skipped
+func readMembershipPairs(file string) ([]membershipPair, error) {
+	records, err := helpers.ReadCsvRecords(file, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	var pairs []membershipPair
+	for i, record := range records {
+		if i == 0 && helpers.IsCsvHeader(record) {
+			continue
+		}
+		user := strings.TrimSpace(record[0])
+		group := strings.TrimSpace(record[1])
+		if user == "" || group == "" {
+			return nil, fmt.Errorf("row %d: username and group must not be empty", i+1)
+		}
+		pairs = append(pairs, membershipPair{user: user, group: group})
+	}
+	return pairs, nil
+}
+
+// addMembershipPairs adds every pair, skipping pairs where the user is already a member,
+// and prints a summary of added, already-member and failed pairs
+func addMembershipPairs(ctx context.Context, userPool string, pairs []membershipPair) {
+	var added, alreadyMember int
+	var failed []string
+
+	// Current memberships, fetched once per user
+	memberships := make(map[string]map[string]bool)
+
+	for _, pair := range pairs {
+		if _, ok := memberships[pair.user]; !ok {
+			groups, err := common.GetGroupsForUser(userPool, pair.user, config.AwsConfig, ctx)
+			if err != nil {
+				failed = append(failed, fmt.Sprintf("%s → %s: %v", pair.user, pair.group, err))
+				continue
+			}
+			memberships[pair.user] = make(map[string]bool)
+			for _, group := range groups {
+				memberships[pair.user][group] = true
+			}
+		}
+
+		if memberships[pair.user][pair.group] {
+			alreadyMember++
+			continue
+		}
+
+		err := common.AddUserToGroup(userPool, pair.user, pair.group, config.AwsConfig)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s → %s: %v", pair.user, pair.group, err))
+			continue
+		}
+		memberships[pair.user][pair.group] = true
+		added++
+		helpers.PrintSuccessLog(fmt.Sprintf("User %s added to group %s\n", pair.user, pair.group))
+	}
+
+	fmt.Printf("\nAdded: %d, already member: %d, failed: %d\n", added, alreadyMember, len(failed))
+	for _, failure := range failed {
+		helpers.PrintWarningErrorLog(failure)
+	}
 }
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// autheventsCmd represents the authevents command
+var autheventsCmd = &cobra.Command{
+	Use:   "authevents",
+	Short: "Browse the sign-in history of a user and give feedback on events",
+	Long: `The "authevents" command shows the authentication events of a user in a scrollable table
+with risk level, risk decision, challenge results, IP address, device and location.
+
+Select an event with enter to mark it as valid or invalid. The feedback trains the
+risk model of Cognito advanced security features. Events are only recorded when
+advanced security features are active on the user pool.
+
+Run this command with "--limit=<n>" to change the number of events loaded (default 50, 0 for all)
+
+Example:
+  cognitousermanagement authevents --limit=200`,
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		ctx := cmd.Context()
+
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+
+		events, err := common.GetAuthEvents(userPool, user, limit, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching authentication events:", err)
+			return
+		}
+		if len(events) == 0 {
+			log.Printf("No authentication events found for user %s.\n", user)
+			return
+		}
+
+		headers := []string{"TIME", "EVENT", "RESPONSE", "RISK", "DECISION", "CHALLENGES", "IP", "DEVICE", "LOCATION", "FEEDBACK"}
+		rows := make([][]string, len(events))
+		for i, event := range events {
+			rows[i] = authEventRow(event)
+		}
+
+		for {
+			chosen := helpers.CallTableView(headers, rows)
+			if chosen < 0 {
+				return
+			}
+
+			event := events[chosen]
+			fmt.Printf("Event %s at %s from %s\n", aws.ToString(event.EventId), rows[chosen][0], rows[chosen][6])
+			// Closing the menu without a choice also returns to the list
+			action := helpers.CallSingleSelect([]string{"Back to the list", "Mark as valid", "Mark as invalid"})
+			if action == "Back to the list" || action == "" {
+				continue
+			}
+
+			valid := action == "Mark as valid"
+			err := common.UpdateAuthEventFeedback(userPool, user, aws.ToString(event.EventId), valid, config.AwsConfig)
+			if err != nil {
+				log.Println("Error sending feedback:", err)
+				continue
+			}
+			if valid {
+				rows[chosen][9] = string(types.FeedbackValueTypeValid)
+			} else {
+				rows[chosen][9] = string(types.FeedbackValueTypeInvalid)
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("Event %s marked as %s\n", aws.ToString(event.EventId), rows[chosen][9]))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(autheventsCmd)
+	autheventsCmd.Flags().Int("limit", 50, "Maximum number of events to load, 0 for all")
+}
+
+// authEventRow converts an authentication event into the cells of a table row
+func authEventRow(event types.AuthEventType) []string {
+	var challenges []string
+	for _, challenge := range event.ChallengeResponses {
+		challenges = append(challenges, fmt.Sprintf("%s:%s", challenge.ChallengeName, challenge.ChallengeResponse))
+	}
+
+	var riskLevel, riskDecision string
+	if event.EventRisk != nil {
+		riskLevel = string(event.EventRisk.RiskLevel)
+		riskDecision = string(event.EventRisk.RiskDecision)
+		if aws.ToBool(event.EventRisk.CompromisedCredentialsDetected) {
+			riskDecision += " (compromised credentials)"
+		}
+	}
+
+	var ip, device, location string
+	if event.EventContextData != nil {
+		ip = aws.ToString(event.EventContextData.IpAddress)
+		device = aws.ToString(event.EventContextData.DeviceName)
+		location = strings.Trim(aws.ToString(event.EventContextData.City)+", "+aws.ToString(event.EventContextData.Country), ", ")
+	}
+
+	var feedback string
+	if event.EventFeedback != nil {
+		feedback = string(event.EventFeedback.FeedbackValue)
+	}
+
+	return []string{
+		formatTime(event.CreationDate),
+		string(event.EventType),
+		string(event.EventResponse),
+		riskLevel,
+		riskDecision,
+		strings.Join(challenges, ", "),
+		ip,
+		device,
+		location,
+		feedback,
+	}
+}
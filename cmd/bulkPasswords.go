@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+)
+
+// passwordRow is a single username,password[,permanent] row of a bulk password file
+type passwordRow struct {
+	row       int // number of the row in the file, counting the header
+	user      string
+	password  string
+	permanent bool
+}
+
+// readPasswordRows reads username,password[,permanent] rows from a CSV file
+// A header row starting with "username" is skipped and rows without a permanent column use the given default
+func readPasswordRows(file string, permanent bool) ([]passwordRow, error) {
+	records, err := helpers.ReadCsvRecords(file, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []passwordRow
+	for i, record := range records {
+		if i == 0 && helpers.IsCsvHeader(record) {
+			continue
+		}
+		if len(record) < 2 || len(record) > 3 {
+			return nil, fmt.Errorf("row %d: expected username,password[,permanent], got %d fields", i+1, len(record))
+		}
+
+		row := passwordRow{
+			row:       i + 1,
+			user:      strings.TrimSpace(record[0]),
+			password:  strings.TrimSpace(record[1]),
+			permanent: permanent,
+		}
+		if row.user == "" || row.password == "" {
+			return nil, fmt.Errorf("row %d: username and password must not be empty", i+1)
+		}
+		if len(record) == 3 && strings.TrimSpace(record[2]) != "" {
+			row.permanent, err = strconv.ParseBool(strings.TrimSpace(record[2]))
+			if err != nil {
+				return nil, fmt.Errorf("row %d: invalid permanent value %q, expected true or false", i+1, record[2])
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// setPasswordRows checks every row against the password policy of the pool and the breach corpus,
+// then sets the password of every user and prints a per-row result report.
+// No password is set while any row is refused.
+func setPasswordRows(ctx context.Context, userPool string, rows []passwordRow, passwords passwordOptions) {
+	rowNumbers := make([]int, len(rows))
+	userNames := make([]string, len(rows))
+	rowPasswords := make([]string, len(rows))
+	for i, row := range rows {
+		rowNumbers[i] = row.row
+		userNames[i] = row.user
+		rowPasswords[i] = row.password
+	}
+
+	// Check every password before the first one is set
+	if invalid := reportPasswordViolations(rowNumbers, userNames, rowPasswords, passwords); invalid > 0 {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("%d of %d rows break the password policy of the pool, no passwords were set", invalid, len(rows)))
+	}
+
+	results := make([]string, len(rows))
+	failed, cancelled := 0, 0
+	// Ctrl+C stops before the next row, the remaining rows are reported as not attempted
+	done := helpers.TrackCancellable()
+	for i, row := range rows {
+		if ctx.Err() != nil {
+			results[i] = "cancelled"
+			cancelled++
+			continue
+		}
+		_, err := common.SetUserPassword(userPool, row.user, row.password, row.permanent, config.AwsConfig, ctx)
+		if err != nil {
+			results[i] = fmt.Sprintf("failed: %v", err)
+			failed++
+			continue
+		}
+		results[i] = "set"
+	}
+
+	done()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROW\tUSERNAME\tTYPE\tRESULT")
+	for i, row := range rows {
+		kind := "temporary"
+		if row.permanent {
+			kind = "permanent"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", row.row, row.user, kind, results[i])
+	}
+	w.Flush()
+
+	fmt.Printf("\nSet: %d, failed: %d, cancelled: %d\n", len(rows)-failed-cancelled, failed, cancelled)
+}
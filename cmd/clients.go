@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// clientsCmd represents the clients command
+var clientsCmd = &cobra.Command{
+	Use:   "clients",
+	Short: "Inspect the app clients of a Cognito User Pool",
+	Long: `The "clients" command shows how the app clients of a Cognito User Pool are configured.
+
+Available subcommands:
+  list       List every app client with its auth flows, OAuth scopes and token validity
+  describe   Show the full configuration of a single app client
+
+Example:
+  cognitousermanagement clients list`,
+}
+
+// clientsListCmd represents the clients list command
+var clientsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the app clients of a user pool",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+
+		clients, err := common.GetPoolClients(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching app clients:", err)
+			return
+		}
+		fmt.Printf("%v app clients found in the pool %v\n", len(clients), userPool)
+		if len(clients) == 0 {
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tCLIENT ID\tSECRET\tAUTH FLOWS\tOAUTH SCOPES\tACCESS/ID/REFRESH")
+		for _, description := range clients {
+			client, err := common.DescribePoolClient(userPool, aws.ToString(description.ClientId), config.AwsConfig, ctx)
+			if err != nil {
+				log.Println("Error describing app client:", err)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\t%s\n",
+				aws.ToString(client.ClientName),
+				aws.ToString(client.ClientId),
+				client.ClientSecret != nil,
+				joinOrDash(explicitAuthFlows(client)),
+				joinOrDash(client.AllowedOAuthScopes),
+				strings.Join(tokenValidities(client), " / "))
+		}
+		w.Flush()
+	},
+}
+
+// clientsDescribeCmd represents the clients describe command
+var clientsDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show the full configuration of an app client",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+		clientId := selectPoolClient(ctx, userPool)
+
+		client, err := common.DescribePoolClient(userPool, clientId, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error describing app client:", err)
+			return
+		}
+
+		validities := tokenValidities(client)
+		var oauthFlows []string
+		for _, flow := range client.AllowedOAuthFlows {
+			oauthFlows = append(oauthFlows, string(flow))
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Name:\t%s\n", aws.ToString(client.ClientName))
+		fmt.Fprintf(w, "Client ID:\t%s\n", aws.ToString(client.ClientId))
+		fmt.Fprintf(w, "Has secret:\t%t\n", client.ClientSecret != nil)
+		fmt.Fprintf(w, "Auth flows:\t%s\n", joinOrDash(explicitAuthFlows(client)))
+		fmt.Fprintf(w, "OAuth enabled:\t%t\n", aws.ToBool(client.AllowedOAuthFlowsUserPoolClient))
+		fmt.Fprintf(w, "OAuth flows:\t%s\n", joinOrDash(oauthFlows))
+		fmt.Fprintf(w, "OAuth scopes:\t%s\n", joinOrDash(client.AllowedOAuthScopes))
+		fmt.Fprintf(w, "Identity providers:\t%s\n", joinOrDash(client.SupportedIdentityProviders))
+		fmt.Fprintf(w, "Callback URLs:\t%s\n", joinOrDash(client.CallbackURLs))
+		fmt.Fprintf(w, "Default redirect URI:\t%s\n", aws.ToString(client.DefaultRedirectURI))
+		fmt.Fprintf(w, "Logout URLs:\t%s\n", joinOrDash(client.LogoutURLs))
+		fmt.Fprintf(w, "Access token validity:\t%s\n", validities[0])
+		fmt.Fprintf(w, "ID token validity:\t%s\n", validities[1])
+		fmt.Fprintf(w, "Refresh token validity:\t%s\n", validities[2])
+		fmt.Fprintf(w, "Auth session validity:\t%d minutes\n", aws.ToInt32(client.AuthSessionValidity))
+		fmt.Fprintf(w, "Token revocation:\t%t\n", aws.ToBool(client.EnableTokenRevocation))
+		fmt.Fprintf(w, "Prevent user existence errors:\t%s\n", client.PreventUserExistenceErrors)
+		fmt.Fprintf(w, "Read attributes:\t%s\n", joinOrDash(client.ReadAttributes))
+		fmt.Fprintf(w, "Write attributes:\t%s\n", joinOrDash(client.WriteAttributes))
+		fmt.Fprintf(w, "Created:\t%s\n", formatTime(client.CreationDate))
+		fmt.Fprintf(w, "Last modified:\t%s\n", formatTime(client.LastModifiedDate))
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(clientsCmd)
+	clientsCmd.AddCommand(clientsListCmd, clientsDescribeCmd)
+}
+
+// selectPoolClient lets the user pick one of the app clients of a user pool and returns its ID
+func selectPoolClient(ctx context.Context, userPool string) string {
+	clients, err := common.GetPoolClients(userPool, config.AwsConfig, ctx)
+	if err != nil {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("Error fetching app clients: %v", err))
+	}
+	if len(clients) == 0 {
+		helpers.PrintFatalErrorLog("No app clients found in the selected user pool.")
+	}
+
+	var labels []string
+	ids := make(map[string]string)
+	for _, client := range clients {
+		label := fmt.Sprintf("%s (%s)", aws.ToString(client.ClientName), aws.ToString(client.ClientId))
+		labels = append(labels, label)
+		ids[label] = aws.ToString(client.ClientId)
+	}
+	label := helpers.CallSingleSelect(labels)
+	if label == "" {
+		helpers.PrintFatalErrorLog("No app client selected")
+	}
+	return ids[label]
+}
+
+// explicitAuthFlows returns the explicit auth flows of an app client as strings
+func explicitAuthFlows(client *types.UserPoolClientType) []string {
+	var flows []string
+	for _, flow := range client.ExplicitAuthFlows {
+		flows = append(flows, string(flow))
+	}
+	return flows
+}
+
+// tokenValidities formats the access, ID and refresh token validity of an app client,
+// applying the Cognito default units of hours, hours and days when none are set
+func tokenValidities(client *types.UserPoolClientType) []string {
+	units := types.TokenValidityUnitsType{
+		AccessToken:  types.TimeUnitsTypeHours,
+		IdToken:      types.TimeUnitsTypeHours,
+		RefreshToken: types.TimeUnitsTypeDays,
+	}
+	if client.TokenValidityUnits != nil {
+		if client.TokenValidityUnits.AccessToken != "" {
+			units.AccessToken = client.TokenValidityUnits.AccessToken
+		}
+		if client.TokenValidityUnits.IdToken != "" {
+			units.IdToken = client.TokenValidityUnits.IdToken
+		}
+		if client.TokenValidityUnits.RefreshToken != "" {
+			units.RefreshToken = client.TokenValidityUnits.RefreshToken
+		}
+	}
+
+	// Cognito omits unset validities, which then default to 1 hour and 30 days
+	access, id := int32(1), int32(1)
+	if client.AccessTokenValidity != nil {
+		access = *client.AccessTokenValidity
+	}
+	if client.IdTokenValidity != nil {
+		id = *client.IdTokenValidity
+	}
+	refresh := client.RefreshTokenValidity
+	if refresh == 0 {
+		refresh = 30
+	}
+
+	return []string{
+		fmt.Sprintf("%d %s", access, units.AccessToken),
+		fmt.Sprintf("%d %s", id, units.IdToken),
+		fmt.Sprintf("%d %s", refresh, units.RefreshToken),
+	}
+}
+
+// joinOrDash joins values with commas, or returns a dash when there are none
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// compareusersCmd represents the compareusers command
+var compareusersCmd = &cobra.Command{
+	Use:   "compareusers",
+	Short: "Compare the groups, attributes, MFA settings and status of two users",
+	Long: `The "compareusers" command shows two users of a Cognito User Pool side by side.
+
+Status, enabled flag, MFA settings, attributes and group memberships are compared.
+Rows only present for the first user are red, rows only present for the second
+user are green and rows with different values are yellow.
+
+Run this command with "--only-differences=true" to hide identical rows.
+
+Example:
+  cognitousermanagement compareusers --only-differences=true`,
+	Run: func(cmd *cobra.Command, args []string) {
+		onlyDifferences, _ := cmd.Flags().GetBool("only-differences")
+		ctx := cmd.Context()
+
+		userPool := selectUserPool(ctx)
+
+		users, err := common.GetUsersFromPool(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching users:", err)
+			return
+		}
+		if len(users) < 2 {
+			log.Println("At least two users are needed for a comparison.")
+			return
+		}
+
+		fmt.Println("Select the first user:")
+		first := helpers.CallSingleSelect(users)
+		if first == "" {
+			log.Println("No user selected.")
+			return
+		}
+		fmt.Println("Select the second user:")
+		second := helpers.CallSingleSelect(slices.DeleteFunc(slices.Clone(users), func(user string) bool {
+			return user == first
+		}))
+		if second == "" {
+			log.Println("No user selected.")
+			return
+		}
+
+		firstProfile, err := userAccessProfile(ctx, userPool, first)
+		if err != nil {
+			log.Println("Error fetching user:", err)
+			return
+		}
+		secondProfile, err := userAccessProfile(ctx, userPool, second)
+		if err != nil {
+			log.Println("Error fetching user:", err)
+			return
+		}
+
+		helpers.PrintDiff(first, second, firstProfile, secondProfile, onlyDifferences)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compareusersCmd)
+	compareusersCmd.Flags().Bool("only-differences", false, "Only show rows that differ between the two users")
+}
+
+// userAccessProfile flattens the status, MFA settings, attributes and groups of a user into key/value pairs
+func userAccessProfile(ctx context.Context, userPool string, userName string) (map[string]string, error) {
+	user, err := common.AdminGetUser(userName, userPool, config.AwsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := common.GetGroupsForUser(userPool, userName, config.AwsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := map[string]string{
+		"status":        string(user.UserStatus),
+		"enabled":       fmt.Sprint(user.Enabled),
+		"mfa.preferred": aws.ToString(user.PreferredMfaSetting),
+		"mfa.enabled":   strings.Join(user.UserMFASettingList, ","),
+	}
+	for _, option := range user.MFAOptions {
+		profile["mfa.legacy."+aws.ToString(option.AttributeName)] = string(option.DeliveryMedium)
+	}
+	for _, attr := range user.UserAttributes {
+		profile["attribute."+aws.ToString(attr.Name)] = aws.ToString(attr.Value)
+	}
+	for _, group := range groups {
+		profile["group."+group] = "member"
+	}
+	return profile, nil
+}
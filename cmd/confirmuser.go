@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// confirmuserCmd represents the confirmuser command
+var confirmuserCmd = &cobra.Command{
+	Use:   "confirmuser",
+	Short: "Confirm the sign-up of unconfirmed Cognito users",
+	Long: `The "confirmuser" command confirms the registration of users that never entered
+their confirmation code.
+
+Only users in the UNCONFIRMED status are listed for selection, and every selected
+user is confirmed with AdminConfirmSignUp.
+
+Run this command with "--verify-email=true" to also mark the email address as verified
+Run this command with "--verify-phone=true" to also mark the phone number as verified
+
+Example:
+  cognitousermanagement confirmuser --verify-email=true`,
+	Run: func(cmd *cobra.Command, args []string) {
+		verifyEmail, _ := cmd.Flags().GetBool("verify-email")
+		verifyPhone, _ := cmd.Flags().GetBool("verify-phone")
+
+		// Get selected user pool from available pools
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching user pools:", err)
+			return
+		}
+		userPool := helpers.CallSingleSelect(userPools)
+		if userPool == "" {
+			helpers.PrintFatalErrorLog("No user pool selected")
+		}
+
+		// Only unconfirmed users can be confirmed
+		users, err := common.GetUsersByStatus(userPool, "UNCONFIRMED", config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching users:", err)
+			return
+		}
+		if len(users) == 0 {
+			log.Println("No unconfirmed users found in the selected user pool.")
+			return
+		}
+		fmt.Println("Select users to confirm:")
+		selectedUsers := helpers.CallMultiSelect(users)
+		if len(selectedUsers) == 0 {
+			log.Println("No users selected.")
+			return
+		}
+
+		// Collect the contact attributes to mark as verified
+		verified := make(map[string]string)
+		if verifyEmail {
+			verified["email_verified"] = "true"
+		}
+		if verifyPhone {
+			verified["phone_number_verified"] = "true"
+		}
+
+		for _, user := range selectedUsers {
+			err := common.ConfirmSignUp(userPool, user, config.AwsConfig)
+			if err != nil {
+				log.Println("Error confirming user:", err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("User %s confirmed\n", user))
+
+			if len(verified) > 0 {
+				err = common.UpdateUserAttributes(userPool, user, verified, config.AwsConfig)
+				if err != nil {
+					log.Println("Error verifying contact attributes:", err)
+					continue
+				}
+				helpers.PrintSuccessLog(fmt.Sprintf("Contact attributes of user %s marked as verified\n", user))
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(confirmuserCmd)
+	confirmuserCmd.Flags().Bool("verify-email", false, "Mark the email address of confirmed users as verified")
+	confirmuserCmd.Flags().Bool("verify-phone", false, "Mark the phone number of confirmed users as verified")
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// uncopyableAttributes are attributes that identify or verify a single user and are never copied,
+// the sign-in and alias attributes of the pool are excluded as well
+var uncopyableAttributes = []string{"sub", "identities", "email", "email_verified", "phone_number", "phone_number_verified", "preferred_username"}
+
+// copyaccessCmd represents the copyaccess command
+var copyaccessCmd = &cobra.Command{
+	Use:   "copyaccess",
+	Short: "Give one or more users the same groups and attributes as another user",
+	Long: `The "copyaccess" command copies the access of a source user to one or more target users.
+
+It will:
+1. Let you select a user pool and the source user
+2. Let you select the target users
+3. Let you select which of the source user's attributes to copy, if any
+4. Add the target users to every group the source user belongs to
+
+Identity and verification attributes such as sub, email, phone_number and their verified flags,
+as well as the sign-in and alias attributes of the pool, are never offered for copying.
+
+Run this command with "--prune=true" to also remove the target users from groups
+the source user does not belong to.
+
+Example:
+  cognitousermanagement copyaccess --prune=true`,
+	Run: func(cmd *cobra.Command, args []string) {
+		prune, _ := cmd.Flags().GetBool("prune")
+		ctx := cmd.Context()
+
+		userPool := selectUserPool(ctx)
+
+		users, err := common.GetUsersFromPool(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching users:", err)
+			return
+		}
+		if len(users) < 2 {
+			log.Println("At least two users are needed to copy access.")
+			return
+		}
+
+		fmt.Println("Select the user to copy access from:")
+		source := helpers.CallSingleSelect(users)
+		if source == "" {
+			log.Println("No source user selected.")
+			return
+		}
+
+		fmt.Println("Select the users to copy access to:")
+		targets := helpers.CallMultiSelect(slices.DeleteFunc(slices.Clone(users), func(user string) bool {
+			return user == source
+		}))
+		if len(targets) == 0 {
+			log.Println("No target users selected.")
+			return
+		}
+
+		sourceGroups, err := common.GetGroupsForUser(userPool, source, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching groups of source user:", err)
+			return
+		}
+		sourceUser, err := common.AdminGetUser(source, userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching source user:", err)
+			return
+		}
+
+		pool, err := common.DescribeUserPool(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching user pool:", err)
+			return
+		}
+		// Sign-in and alias attributes must be unique, copying them would fail or move them to the target
+		excluded := slices.Clone(uncopyableAttributes)
+		for _, attr := range pool.UsernameAttributes {
+			excluded = append(excluded, string(attr))
+		}
+		for _, attr := range pool.AliasAttributes {
+			excluded = append(excluded, string(attr))
+		}
+
+		// Offer the source attributes that can be copied to another user
+		sourceAttributes := make(map[string]string)
+		var attributeNames []string
+		for _, attr := range sourceUser.UserAttributes {
+			name := aws.ToString(attr.Name)
+			if slices.Contains(excluded, name) {
+				continue
+			}
+			sourceAttributes[name] = aws.ToString(attr.Value)
+			attributeNames = append(attributeNames, name)
+		}
+		attributes := make(map[string]string)
+		if len(attributeNames) > 0 {
+			fmt.Println("Select attributes to copy (press q without selecting to skip):")
+			for _, name := range helpers.CallMultiSelect(attributeNames) {
+				attributes[name] = sourceAttributes[name]
+			}
+		}
+
+		if !helpers.Confirm(fmt.Sprintf("Copy %d group(s) and %d attribute(s) from %s to %d user(s)?", len(sourceGroups), len(attributes), source, len(targets))) {
+			helpers.PrintWarningErrorLog("Copy cancelled.")
+			return
+		}
+
+		// Add every target to every group of the source
+		var pairs []membershipPair
+		for _, target := range targets {
+			for _, group := range sourceGroups {
+				pairs = append(pairs, membershipPair{user: target, group: group})
+			}
+		}
+		if len(pairs) > 0 {
+			addMembershipPairs(ctx, userPool, pairs)
+		}
+
+		for _, target := range targets {
+			if len(attributes) > 0 {
+				err = common.UpdateUserAttributes(userPool, target, attributes, config.AwsConfig)
+				if err != nil {
+					log.Println("Error copying attributes:", err)
+				} else {
+					helpers.PrintSuccessLog(fmt.Sprintf("Attributes copied to user %s\n", target))
+				}
+			}
+
+			if !prune {
+				continue
+			}
+			// Remove the memberships the source user does not have
+			targetGroups, err := common.GetGroupsForUser(userPool, target, config.AwsConfig, ctx)
+			if err != nil {
+				log.Println("Error fetching groups of target user:", err)
+				continue
+			}
+			for _, group := range targetGroups {
+				if slices.Contains(sourceGroups, group) {
+					continue
+				}
+				err = common.RemoveUserFromGroup(userPool, target, group, config.AwsConfig)
+				if err != nil {
+					log.Println("Error removing user from group:", err)
+					continue
+				}
+				helpers.PrintSuccessLog(fmt.Sprintf("User %s removed from group %s\n", target, group))
+			}
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(copyaccessCmd)
+	copyaccessCmd.Flags().Bool("prune", false, "Remove target users from groups the source user does not belong to")
+}
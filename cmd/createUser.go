@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 	"github.com/ramalabeysekera/cognito-user-management/config"
 	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
 	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
@@ -24,14 +25,36 @@ var createCmd = &cobra.Command{
 
 Run this command with "--permanentpassword=true" to set a permanant password during the creation
 Run this command with "--bulk=true" to create multiple users from a CSV file
+Run this command with "--invite=email|sms|both|none" to choose how the invitation message is delivered (default none)
+Run this command with "--force-alias=true" to move an email or phone alias already used by another user
+Run this command with "--generate-password" or "--generate-password=passphrase" to generate a password for every user,
+the CSV file of "--bulk=true" then only needs the usernames
+Run this command with "--output=<file>" to write generated credentials to a file instead of showing them once
+Run this command with "--username=<name>" to pass the username instead of entering it
+Run this command with "--password-stdin=true" to read the password from stdin, e.g. piped from a secrets manager CLI,
+the username must then be given with "--username"
+Run this command with "--breach-db=<path>" to refuse passwords listed in a local Have I Been Pwned corpus,
+either a directory of range files or a hash file sorted by hash
+Passwords entered interactively are typed twice and are not shown on the terminal.
 Ensure your AWS credentials are properly configured before running this command.
 The command uses the AWS SDK for Go (v2) and requires appropriate IAM permissions to access Cognito services`,
 	// Run defines the main execution logic for the create command
 	Run: func(cmd *cobra.Command, args []string) {
+		// Stdin is consumed by --password-stdin, so the other answers must come from flags
+		fromStdin, _ := cmd.Flags().GetBool("password-stdin")
+		bulkCreation, _ := cmd.Flags().GetBool("bulk")
+		userName, _ := cmd.Flags().GetString("username")
+		if fromStdin && bulkCreation {
+			helpers.PrintFatalErrorLog("--password-stdin cannot be combined with --bulk, put the passwords in the CSV file instead")
+		}
+		if fromStdin && strings.TrimSpace(userName) == "" {
+			helpers.PrintFatalErrorLog("--password-stdin requires --username, the username cannot be read from stdin as well")
+		}
+
 		// Get selected user pool from available pools
 		fmt.Println("Select a user pool you want to create the user in:")
 		// Get selected user pool from available pools
-		userPools, err := common.GetAllPools(config.AwsConfig)
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
 		if err != nil {
 			log.Println("Error fetching user pools:", err)
 			return
@@ -40,10 +63,23 @@ The command uses the AWS SDK for Go (v2) and requires appropriate IAM permission
 		if userPool != "" {
 			// Check if permanent password flag is set
 			permanentpassword, _ := cmd.Flags().GetBool("permanentpassword")
-			// Check if bulk flag is set
-			bulkCreation, _ := cmd.Flags().GetBool("bulk")
+			// Work out how the invitation should be delivered
+			inviteFlag, _ := cmd.Flags().GetString("invite")
+			invite, err := inviteOptionsFromFlag(inviteFlag)
+			if err != nil {
+				helpers.PrintFatalErrorLog(err.Error())
+			}
+			invite.ForceAliasCreation, _ = cmd.Flags().GetBool("force-alias")
+			// Read the password policy so passwords are checked or generated before anything is sent
+			generateFlag, _ := cmd.Flags().GetString("generate-password")
+			output, _ := cmd.Flags().GetString("output")
+			breachDb, _ := cmd.Flags().GetString("breach-db")
+			passwords, err := newPasswordOptions(generateFlag, output, fromStdin, breachDb, fetchPasswordPolicy(cmd.Context(), userPool))
+			if err != nil {
+				helpers.PrintFatalErrorLog(err.Error())
+			}
 			// Get user sign-in attributes for the pool
-			attrs, err := common.DescribeUserSignInAttr(&userPool, config.AwsConfig, context.Background())
+			attrs, err := common.DescribeUserSignInAttr(&userPool, config.AwsConfig, cmd.Context())
 
 			if err != nil {
 				helpers.PrintFatalErrorLog(err.Error())
@@ -54,20 +90,23 @@ The command uses the AWS SDK for Go (v2) and requires appropriate IAM permission
 				if len(attrs) > 1 {
 					// If multiple attributes available, let user select one
 					selectedAttr := helpers.CallSingleSelect(attrs)
+					if selectedAttr == "" {
+						helpers.PrintFatalErrorLog("No sign-in attribute selected")
+					}
 
 					// Map attribute names to friendly display names
 					attToFriendlyName := make(map[string](string))
 					attToFriendlyName["email"] = "Email"
 					attToFriendlyName["phone_number"] = "Phone Number"
 
-					createCognitoUser(context.Background(), userPool, permanentpassword, attToFriendlyName[selectedAttr], bulkCreation)
+					createCognitoUser(cmd.Context(), userPool, permanentpassword, attToFriendlyName[selectedAttr], userName, bulkCreation, invite, passwords)
 				} else {
 					// If only one attribute, use it directly
-					createCognitoUser(context.Background(), userPool, permanentpassword, attrs[0], bulkCreation)
+					createCognitoUser(cmd.Context(), userPool, permanentpassword, attrs[0], userName, bulkCreation, invite, passwords)
 				}
 			} else {
 				// If no attributes, create user without attribute
-				createCognitoUser(context.Background(), userPool, permanentpassword, "", bulkCreation)
+				createCognitoUser(cmd.Context(), userPool, permanentpassword, "", userName, bulkCreation, invite, passwords)
 			}
 		} else {
 			helpers.PrintFatalErrorLog("No user pool ID found")
@@ -81,6 +120,32 @@ func init() {
 	rootCmd.AddCommand(createCmd)
 	createCmd.Flags().Bool("permanentpassword", false, "Set password as permanant for the new user")
 	createCmd.Flags().Bool("bulk", false, "Read the user attributes from a file and create")
+	createCmd.Flags().String("invite", "none", "Deliver the invitation message via email, sms, both or none")
+	createCmd.Flags().Bool("force-alias", false, "Move an email or phone alias already used by another user to the new user")
+	createCmd.Flags().String("generate-password", "", "Generate a password or passphrase for every user instead of reading one")
+	createCmd.Flags().Lookup("generate-password").NoOptDefVal = "password"
+	createCmd.Flags().String("output", "", "Write generated credentials to this file instead of showing them")
+	createCmd.Flags().String("username", "", "Username of the new user, asked for when omitted")
+	createCmd.Flags().Bool("password-stdin", false, "Read the password from stdin")
+	createCmd.Flags().String("breach-db", "", "Refuse passwords listed in this local breach corpus, a range file directory or sorted hash file")
+	createCmd.MarkFlagsMutuallyExclusive("generate-password", "password-stdin")
+}
+
+// inviteOptionsFromFlag converts the value of the --invite flag into invitation options
+// "none" suppresses the invitation message, any other value sends it through the given channels
+func inviteOptionsFromFlag(value string) (common.InviteOptions, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "none", "":
+		return common.InviteOptions{MessageAction: types.MessageActionTypeSuppress}, nil
+	case "email":
+		return common.InviteOptions{DeliveryMediums: []types.DeliveryMediumType{types.DeliveryMediumTypeEmail}}, nil
+	case "sms":
+		return common.InviteOptions{DeliveryMediums: []types.DeliveryMediumType{types.DeliveryMediumTypeSms}}, nil
+	case "both":
+		return common.InviteOptions{DeliveryMediums: []types.DeliveryMediumType{types.DeliveryMediumTypeEmail, types.DeliveryMediumTypeSms}}, nil
+	default:
+		return common.InviteOptions{}, fmt.Errorf("invalid --invite value %q, expected email, sms, both or none", value)
+	}
 }
 
 // createCognitoUser handles the creation of a new user in AWS Cognito
@@ -89,7 +154,11 @@ func init() {
 // - userPoolId: ID of the Cognito user pool
 // - permpass: Boolean indicating if password should be permanent
 // - attr: User attribute to be used (email/phone)
-func createCognitoUser(ctx context.Context, userPoolId string, permpass bool, attr string, bulk bool) {
+// - userName: Username of the new user, empty to ask for it
+// - bulk: Boolean indicating if users are read from a CSV file
+// - invite: How the invitation message is delivered to the new users
+// - passwords: Password policy of the pool, checked before any user is created, and the password generator
+func createCognitoUser(ctx context.Context, userPoolId string, permpass bool, attr string, userName string, bulk bool, invite common.InviteOptions, passwords passwordOptions) {
 
 	// Set up input reader for user interaction
 	reader := bufio.NewReader(os.Stdin)
@@ -97,7 +166,7 @@ func createCognitoUser(ctx context.Context, userPoolId string, permpass bool, at
 	fmt.Println("Attempting to create the user on userPoolId:", userPoolId)
 	fmt.Println("Cancel the operation if this is not intended - Ctrl+C")
 
-	var userName, tempPassword string
+	var tempPassword string
 
 	var err error
 
@@ -105,19 +174,45 @@ func createCognitoUser(ctx context.Context, userPoolId string, permpass bool, at
 
 	if bulk {
 		// Read users from CSV file
-		userList, tempPasswordList = helpers.ReadUsersFromCsv(userList, tempPasswordList)
+		if passwords.generate != nil {
+			// Generate a password for every user listed in the file
+			userList = helpers.ReadUsernamesFromCsv()
+			for range userList {
+				password, err := passwords.generate()
+				if err != nil {
+					helpers.PrintFatalErrorLog(err.Error())
+				}
+				tempPasswordList = append(tempPasswordList, password)
+			}
+		} else {
+			var rowNumbers []int
+			userList, tempPasswordList, rowNumbers = helpers.ReadUsersFromCsv(userList, tempPasswordList)
+
+			// Check every password before the first user is created
+			if invalid := reportPasswordViolations(rowNumbers, userList, tempPasswordList, passwords); invalid > 0 {
+				helpers.PrintFatalErrorLog(fmt.Sprintf("%d of %d rows break the password policy of the pool, no users were created", invalid, len(userList)))
+			}
+		}
 
-		// Create users in bulk
+		// Create users in bulk, Ctrl+C stops before the next user
+		done := helpers.TrackCancellable()
 		for i := 0; i < len(userList); i++ {
+			if ctx.Err() != nil {
+				helpers.PrintWarningErrorLog(fmt.Sprintf("Cancelled, %d of %d users were not created", len(userList)-i, len(userList)))
+				break
+			}
 			userName = strings.TrimSpace(userList[i])
 			tempPassword = strings.TrimSpace(tempPasswordList[i])
 
 			// Create user
-			err := common.CreateUser(userPoolId, userName, tempPassword, permpass, config.AwsConfig)
+			err := common.CreateUser(userPoolId, userName, tempPassword, permpass, invite, config.AwsConfig)
 
 			if err != nil {
 				log.Printf("Error creating user %s: %v", userName, err)
 			} else {
+				if passwords.generate != nil {
+					passwords.generated.add(userName, tempPassword)
+				}
 				// Handle permanent password setting if requested
 				if permpass {
 
@@ -143,35 +238,49 @@ func createCognitoUser(ctx context.Context, userPoolId string, permpass bool, at
 				}
 			}
 		}
+		done()
 
 	} else {
-		// Prompt for username or attribute value
-		if attr != "" {
-			fmt.Printf("Please enter the %v : ", attr)
-		} else {
-			fmt.Print("Please enter the username: ")
-		}
+		if userName == "" {
+			// Prompt for username or attribute value
+			if attr != "" {
+				fmt.Printf("Please enter the %v : ", attr)
+			} else {
+				fmt.Print("Please enter the username: ")
+			}
 
-		// Read and process username input
-		userName, err = reader.ReadString('\n')
-		if err != nil {
-			log.Print(err)
+			// Read and process username input
+			userName, err = reader.ReadString('\n')
+			if err != nil {
+				helpers.PrintFatalErrorLog(fmt.Sprintf("Error reading username: %v", err))
+			}
+			userName = strings.TrimSpace(userName)
+			if userName == "" {
+				helpers.PrintFatalErrorLog("Username cannot be empty.")
+			}
 		}
-		userName = strings.TrimSpace(userName)
 
-		// Get temporary password
-		fmt.Print(`Please enter the temporary password (Run this command with "--permanentpassword=true" to set a permanant password) : `)
-		tempPassword, err = reader.ReadString('\n')
-		tempPassword = strings.TrimSpace(tempPassword)
-		if err != nil {
-			log.Print(err)
+		// Get temporary password, generated, piped or entered
+		switch {
+		case passwords.stdin != "":
+			tempPassword = passwords.stdin
+		case passwords.generate != nil:
+			tempPassword, err = passwords.generate()
+			if err != nil {
+				helpers.PrintFatalErrorLog(err.Error())
+			}
+		default:
+			tempPassword = readValidPassword(`Please enter the temporary password (Run this command with "--permanentpassword=true" to set a permanant password)`, passwords)
 		}
 
-		err := common.CreateUser(userPoolId, userName, tempPassword, permpass, config.AwsConfig)
+		err := common.CreateUser(userPoolId, userName, tempPassword, permpass, invite, config.AwsConfig)
 
 		if err != nil {
 			helpers.PrintFatalErrorLog(err.Error())
 		} else {
+			if passwords.generate != nil {
+				passwords.generated.add(userName, tempPassword)
+			}
 
 			// Handle permanent password setting if requested
 			if permpass {
@@ -197,4 +306,7 @@ func createCognitoUser(ctx context.Context, userPoolId string, permpass bool, at
 			}
 		}
 	}
+
+	// Show the generated credentials once every user is processed
+	passwords.generated.reveal()
 }
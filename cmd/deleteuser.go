@@ -34,7 +34,7 @@ Example:
 	Run: func(cmd *cobra.Command, args []string) {
 		// Get user pool selection from user
 		// Get selected user pool from available pools
-		userPools, err := common.GetAllPools(config.AwsConfig)
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
 		if err != nil {
 			log.Println("Error fetching user pools:", err)
 			return
@@ -42,7 +42,7 @@ Example:
 		userPool := helpers.CallSingleSelect(userPools)
 		if userPool != "" {
 			// Fetch all users from the selected pool
-			users, err := common.GetUsersFromPool(userPool, config.AwsConfig)
+			users, err := common.GetUsersFromPool(userPool, config.AwsConfig, cmd.Context())
 			if err != nil {
 				log.Println("Error fetching users:", err)
 				return
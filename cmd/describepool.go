@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/fatih/color"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// describepoolCmd represents the describepool command
+var describepoolCmd = &cobra.Command{
+	Use:   "describepool",
+	Short: "Show the full configuration of a Cognito User Pool",
+	Long: `The "describepool" command renders the whole configuration of a selected user pool:
+
+- General settings, deletion protection and estimated number of users
+- Sign-in, alias and username attributes
+- Password policy
+- MFA mode and advanced security mode
+- Email and SMS configuration
+- Lambda triggers
+- Account recovery
+- Schema attributes
+
+Example:
+  cognitousermanagement describepool`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+
+		pool, err := common.DescribeUserPool(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error describing user pool:", err)
+			return
+		}
+
+		printPoolReport(os.Stdout, pool)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(describepoolCmd)
+}
+
+// printPoolReport writes the configuration of a user pool as titled sections
+func printPoolReport(out io.Writer, pool *types.UserPoolType) {
+	title := color.New(color.FgCyan, color.Bold).SprintFunc()
+	section := func(name string, rows [][2]string) {
+		fmt.Fprintf(out, "\n%s\n", title(name))
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, row := range rows {
+			fmt.Fprintf(w, "  %s:\t%s\n", row[0], row[1])
+		}
+		w.Flush()
+	}
+
+	section("General", [][2]string{
+		{"Name", aws.ToString(pool.Name)},
+		{"ID", aws.ToString(pool.Id)},
+		{"ARN", aws.ToString(pool.Arn)},
+		{"Tier", string(pool.UserPoolTier)},
+		{"Estimated users", fmt.Sprint(pool.EstimatedNumberOfUsers)},
+		{"Deletion protection", string(pool.DeletionProtection)},
+		{"Domain", orDash(aws.ToString(pool.Domain))},
+		{"Custom domain", orDash(aws.ToString(pool.CustomDomain))},
+		{"Created", formatTime(pool.CreationDate)},
+		{"Last modified", formatTime(pool.LastModifiedDate)},
+	})
+
+	signIn := [][2]string{
+		{"Username attributes", joinOrDash(enumStrings(pool.UsernameAttributes))},
+		{"Alias attributes", joinOrDash(enumStrings(pool.AliasAttributes))},
+		{"Auto-verified attributes", joinOrDash(enumStrings(pool.AutoVerifiedAttributes))},
+	}
+	if pool.UsernameConfiguration != nil {
+		signIn = append(signIn, [2]string{"Case sensitive usernames", fmt.Sprint(aws.ToBool(pool.UsernameConfiguration.CaseSensitive))})
+	}
+	if pool.AdminCreateUserConfig != nil {
+		signIn = append(signIn, [2]string{"Admin-only sign-up", fmt.Sprint(pool.AdminCreateUserConfig.AllowAdminCreateUserOnly)})
+	}
+	if pool.UserAttributeUpdateSettings != nil {
+		signIn = append(signIn, [2]string{"Verify before update", joinOrDash(enumStrings(pool.UserAttributeUpdateSettings.AttributesRequireVerificationBeforeUpdate))})
+	}
+	if pool.DeviceConfiguration != nil {
+		signIn = append(signIn,
+			[2]string{"Challenge on new device", fmt.Sprint(pool.DeviceConfiguration.ChallengeRequiredOnNewDevice)},
+			[2]string{"Remember devices on prompt", fmt.Sprint(pool.DeviceConfiguration.DeviceOnlyRememberedOnUserPrompt)})
+	}
+	section("Sign-in", signIn)
+
+	if pool.Policies != nil && pool.Policies.PasswordPolicy != nil {
+		policy := pool.Policies.PasswordPolicy
+		section("Password policy", [][2]string{
+			{"Minimum length", fmt.Sprint(aws.ToInt32(policy.MinimumLength))},
+			{"Require uppercase", fmt.Sprint(policy.RequireUppercase)},
+			{"Require lowercase", fmt.Sprint(policy.RequireLowercase)},
+			{"Require numbers", fmt.Sprint(policy.RequireNumbers)},
+			{"Require symbols", fmt.Sprint(policy.RequireSymbols)},
+			{"Password history size", fmt.Sprint(aws.ToInt32(policy.PasswordHistorySize))},
+			{"Temporary password validity", fmt.Sprintf("%d days", policy.TemporaryPasswordValidityDays)},
+		})
+	}
+
+	security := [][2]string{
+		{"MFA", string(pool.MfaConfiguration)},
+		{"SMS MFA message", orDash(aws.ToString(pool.SmsAuthenticationMessage))},
+	}
+	if pool.UserPoolAddOns != nil {
+		security = append(security, [2]string{"Advanced security", string(pool.UserPoolAddOns.AdvancedSecurityMode)})
+	}
+	section("Security", security)
+
+	email := [][2]string{}
+	if pool.EmailConfiguration != nil {
+		email = append(email,
+			[2]string{"Sending account", string(pool.EmailConfiguration.EmailSendingAccount)},
+			[2]string{"From", orDash(aws.ToString(pool.EmailConfiguration.From))},
+			[2]string{"Reply-to", orDash(aws.ToString(pool.EmailConfiguration.ReplyToEmailAddress))},
+			[2]string{"SES identity", orDash(aws.ToString(pool.EmailConfiguration.SourceArn))},
+			[2]string{"Configuration set", orDash(aws.ToString(pool.EmailConfiguration.ConfigurationSet))})
+	}
+	if pool.EmailConfigurationFailure != nil {
+		email = append(email, [2]string{"Failure", aws.ToString(pool.EmailConfigurationFailure)})
+	}
+	section("Email", email)
+
+	sms := [][2]string{}
+	if pool.SmsConfiguration != nil {
+		sms = append(sms,
+			[2]string{"SNS caller role", orDash(aws.ToString(pool.SmsConfiguration.SnsCallerArn))},
+			[2]string{"External ID", orDash(aws.ToString(pool.SmsConfiguration.ExternalId))},
+			[2]string{"SNS region", orDash(aws.ToString(pool.SmsConfiguration.SnsRegion))})
+	}
+	if pool.SmsConfigurationFailure != nil {
+		sms = append(sms, [2]string{"Failure", aws.ToString(pool.SmsConfigurationFailure)})
+	}
+	section("SMS", sms)
+
+	section("Lambda triggers", lambdaTriggers(pool.LambdaConfig))
+
+	recovery := [][2]string{}
+	if pool.AccountRecoverySetting != nil {
+		for _, mechanism := range pool.AccountRecoverySetting.RecoveryMechanisms {
+			recovery = append(recovery, [2]string{fmt.Sprintf("Priority %d", aws.ToInt32(mechanism.Priority)), string(mechanism.Name)})
+		}
+	}
+	section("Account recovery", recovery)
+
+	fmt.Fprintf(out, "\n%s\n", title("Schema attributes"))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tTYPE\tREQUIRED\tMUTABLE\tDEVELOPER ONLY\tCONSTRAINTS")
+	for _, attr := range pool.SchemaAttributes {
+		fmt.Fprintf(w, "  %s\t%s\t%t\t%t\t%t\t%s\n",
+			aws.ToString(attr.Name),
+			attr.AttributeDataType,
+			aws.ToBool(attr.Required),
+			aws.ToBool(attr.Mutable),
+			aws.ToBool(attr.DeveloperOnlyAttribute),
+			schemaConstraints(attr))
+	}
+	w.Flush()
+
+	if len(pool.UserPoolTags) > 0 {
+		var tags [][2]string
+		for _, key := range sortedKeys(pool.UserPoolTags) {
+			tags = append(tags, [2]string{key, pool.UserPoolTags[key]})
+		}
+		section("Tags", tags)
+	}
+	if pool.EmailConfigurationFailure != nil || pool.SmsConfigurationFailure != nil {
+		helpers.PrintWarningErrorLog("The user pool reports email or SMS configuration failures")
+	}
+}
+
+// lambdaTriggers lists the configured Lambda triggers of a user pool
+func lambdaTriggers(lambda *types.LambdaConfigType) [][2]string {
+	if lambda == nil {
+		return nil
+	}
+	var rows [][2]string
+	add := func(name string, arn *string) {
+		if arn != nil {
+			rows = append(rows, [2]string{name, *arn})
+		}
+	}
+	add("Pre sign-up", lambda.PreSignUp)
+	add("Custom message", lambda.CustomMessage)
+	add("Post confirmation", lambda.PostConfirmation)
+	add("Pre authentication", lambda.PreAuthentication)
+	add("Post authentication", lambda.PostAuthentication)
+	add("Define auth challenge", lambda.DefineAuthChallenge)
+	add("Create auth challenge", lambda.CreateAuthChallenge)
+	add("Verify auth challenge response", lambda.VerifyAuthChallengeResponse)
+	add("Pre token generation", lambda.PreTokenGeneration)
+	if lambda.PreTokenGenerationConfig != nil {
+		add(fmt.Sprintf("Pre token generation (%s)", lambda.PreTokenGenerationConfig.LambdaVersion), lambda.PreTokenGenerationConfig.LambdaArn)
+	}
+	add("User migration", lambda.UserMigration)
+	if lambda.CustomSMSSender != nil {
+		add("Custom SMS sender", lambda.CustomSMSSender.LambdaArn)
+	}
+	if lambda.CustomEmailSender != nil {
+		add("Custom email sender", lambda.CustomEmailSender.LambdaArn)
+	}
+	add("KMS key", lambda.KMSKeyID)
+	return rows
+}
+
+// schemaConstraints formats the length or value constraints of a schema attribute
+func schemaConstraints(attr types.SchemaAttributeType) string {
+	var constraints []string
+	if c := attr.StringAttributeConstraints; c != nil {
+		if c.MinLength != nil || c.MaxLength != nil {
+			constraints = append(constraints, fmt.Sprintf("length %s..%s", orDash(aws.ToString(c.MinLength)), orDash(aws.ToString(c.MaxLength))))
+		}
+	}
+	if c := attr.NumberAttributeConstraints; c != nil {
+		if c.MinValue != nil || c.MaxValue != nil {
+			constraints = append(constraints, fmt.Sprintf("value %s..%s", orDash(aws.ToString(c.MinValue)), orDash(aws.ToString(c.MaxValue))))
+		}
+	}
+	return joinOrDash(constraints)
+}
+
+// enumStrings converts a slice of SDK enum values to plain strings
+func enumStrings[T ~string](values []T) []string {
+	var result []string
+	for _, value := range values {
+		result = append(result, string(value))
+	}
+	return result
+}
+
+// orDash returns a dash for empty values
+func orDash(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
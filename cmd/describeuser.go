@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/spf13/cobra"
+)
+
+// describeuserCmd represents the describeuser command
+var describeuserCmd = &cobra.Command{
+	Use:   "describeuser",
+	Short: "Show the status, attributes, groups and linked identities of a user",
+	Long: `The "describeuser" command shows everything Cognito knows about a selected user:
+status, MFA settings, attributes, group memberships and the external identities
+linked to the user.
+
+Example:
+  cognitousermanagement describeuser`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+
+		output, err := common.AdminGetUser(user, userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching user:", err)
+			return
+		}
+		groups, err := common.GetGroupsForUser(userPool, user, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching groups for user:", err)
+			return
+		}
+		identities, err := common.ParseIdentities(output.UserAttributes)
+		if err != nil {
+			log.Println("Error reading linked identities:", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Username:\t%s\n", aws.ToString(output.Username))
+		fmt.Fprintf(w, "Status:\t%s\n", output.UserStatus)
+		fmt.Fprintf(w, "Enabled:\t%t\n", output.Enabled)
+		fmt.Fprintf(w, "Created:\t%s\n", formatTime(output.UserCreateDate))
+		fmt.Fprintf(w, "Last modified:\t%s\n", formatTime(output.UserLastModifiedDate))
+		fmt.Fprintf(w, "Enabled MFA:\t%s\n", strings.Join(output.UserMFASettingList, ", "))
+		fmt.Fprintf(w, "Preferred MFA:\t%s\n", aws.ToString(output.PreferredMfaSetting))
+		fmt.Fprintf(w, "Groups:\t%s\n", strings.Join(groups, ", "))
+		w.Flush()
+
+		fmt.Println("\nAttributes:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, attr := range output.UserAttributes {
+			// Linked identities are shown in their own table below
+			if aws.ToString(attr.Name) == "identities" {
+				continue
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", aws.ToString(attr.Name), aws.ToString(attr.Value))
+		}
+		w.Flush()
+
+		fmt.Println("\nLinked identities:")
+		if len(identities) == 0 {
+			fmt.Println("  none")
+			return
+		}
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  PROVIDER\tTYPE\tUSER ID\tPRIMARY\tLINKED")
+		for _, identity := range identities {
+			linked := time.UnixMilli(identity.DateCreated)
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%t\t%s\n", identity.ProviderName, identity.ProviderType, identity.UserId, identity.Primary, formatTime(&linked))
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(describeuserCmd)
+}
@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// devicesCmd represents the devices command
+var devicesCmd = &cobra.Command{
+	Use:   "devices",
+	Short: "List, inspect and forget the remembered devices of a user",
+	Long: `The "devices" command manages the devices Cognito remembers for a user.
+
+Available subcommands:
+  list     List the remembered devices of a user with last IP and last sign-in
+  show     Show every attribute of a single device
+  status   Mark a device as remembered or not remembered
+  forget   Forget selected devices, or all devices with --all
+
+Example:
+  cognitousermanagement devices list
+  cognitousermanagement devices forget --all=true`,
+}
+
+// devicesListCmd represents the devices list command
+var devicesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the remembered devices of a user",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+
+		devices, err := common.GetDevices(userPool, user, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching devices:", err)
+			return
+		}
+		fmt.Printf("%v devices found for user %v\n", len(devices), user)
+		if len(devices) == 0 {
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "DEVICE KEY\tNAME\tLAST IP\tLAST AUTHENTICATED\tSTATUS")
+		for _, device := range devices {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
+				aws.ToString(device.DeviceKey),
+				common.DeviceAttribute(device, "device_name"),
+				common.DeviceAttribute(device, "last_ip_used"),
+				formatTime(device.DeviceLastAuthenticatedDate),
+				common.DeviceAttribute(device, "dev:device_remembered_status"))
+		}
+		w.Flush()
+	},
+}
+
+// devicesShowCmd represents the devices show command
+var devicesShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show every attribute of a remembered device",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+		deviceKey := selectDevice(ctx, userPool, user)
+
+		device, err := common.GetDevice(userPool, user, deviceKey, config.AwsConfig)
+		if err != nil {
+			log.Println("Error fetching device:", err)
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Device key:\t%s\n", aws.ToString(device.DeviceKey))
+		fmt.Fprintf(w, "Created:\t%s\n", formatTime(device.DeviceCreateDate))
+		fmt.Fprintf(w, "Last modified:\t%s\n", formatTime(device.DeviceLastModifiedDate))
+		fmt.Fprintf(w, "Last authenticated:\t%s\n", formatTime(device.DeviceLastAuthenticatedDate))
+		for _, attr := range device.DeviceAttributes {
+			fmt.Fprintf(w, "%s:\t%s\n", aws.ToString(attr.Name), aws.ToString(attr.Value))
+		}
+		w.Flush()
+	},
+}
+
+// devicesStatusCmd represents the devices status command
+var devicesStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Mark a device as remembered or not remembered",
+	Long: `Change the remembered status of a device.
+
+A device that is not remembered must complete MFA again at the next sign-in,
+when the user pool only skips MFA on remembered devices.
+
+Run this command with "--remembered=false" to stop remembering the device (default)
+Run this command with "--remembered=true" to remember the device again`,
+	Run: func(cmd *cobra.Command, args []string) {
+		remembered, _ := cmd.Flags().GetBool("remembered")
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+		deviceKey := selectDevice(ctx, userPool, user)
+
+		err := common.UpdateDeviceStatus(userPool, user, deviceKey, remembered, config.AwsConfig)
+		if err != nil {
+			log.Println("Error updating device status:", err)
+			return
+		}
+		if remembered {
+			helpers.PrintSuccessLog(fmt.Sprintf("Device %s of user %s is remembered\n", deviceKey, user))
+		} else {
+			helpers.PrintSuccessLog(fmt.Sprintf("Device %s of user %s is no longer remembered\n", deviceKey, user))
+		}
+	},
+}
+
+// devicesForgetCmd represents the devices forget command
+var devicesForgetCmd = &cobra.Command{
+	Use:   "forget",
+	Short: "Forget remembered devices of a user",
+	Long: `Forget one or more remembered devices of a user, e.g. after a lost laptop report.
+
+Run this command with "--all=true" to forget every device of the user.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+
+		devices, err := common.GetDevices(userPool, user, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching devices:", err)
+			return
+		}
+		if len(devices) == 0 {
+			log.Printf("User %s has no remembered devices.\n", user)
+			return
+		}
+
+		labels, keys := deviceChoices(devices)
+		selected := labels
+		if !all {
+			fmt.Println("Select devices to forget:")
+			selected = helpers.CallMultiSelect(labels)
+			if len(selected) == 0 {
+				log.Println("No devices selected.")
+				return
+			}
+		}
+		if !helpers.Confirm(fmt.Sprintf("Forget %d device(s) of user %s?", len(selected), user)) {
+			helpers.PrintWarningErrorLog("Forget cancelled.")
+			return
+		}
+
+		for _, label := range selected {
+			err := common.ForgetDevice(userPool, user, keys[label], config.AwsConfig)
+			if err != nil {
+				log.Println("Error forgetting device:", err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("Device %s forgotten\n", label))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(devicesCmd)
+	devicesCmd.AddCommand(devicesListCmd, devicesShowCmd, devicesStatusCmd, devicesForgetCmd)
+	devicesStatusCmd.Flags().Bool("remembered", false, "Whether the device should be remembered")
+	devicesForgetCmd.Flags().Bool("all", false, "Forget every device of the user")
+}
+
+// selectDevice lets the user pick one of the remembered devices of a user and returns its key
+func selectDevice(ctx context.Context, userPool string, user string) string {
+	devices, err := common.GetDevices(userPool, user, config.AwsConfig, ctx)
+	if err != nil {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("Error fetching devices: %v", err))
+	}
+	if len(devices) == 0 {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("User %s has no remembered devices.", user))
+	}
+	labels, keys := deviceChoices(devices)
+	label := helpers.CallSingleSelect(labels)
+	if label == "" {
+		helpers.PrintFatalErrorLog("No device selected")
+	}
+	return keys[label]
+}
+
+// deviceChoices builds readable selection labels for devices and maps each label back to its device key
+func deviceChoices(devices []types.DeviceType) ([]string, map[string]string) {
+	var labels []string
+	keys := make(map[string]string)
+	for _, device := range devices {
+		key := aws.ToString(device.DeviceKey)
+		label := fmt.Sprintf("%s (%s, last IP %s, last used %s)", key,
+			common.DeviceAttribute(device, "device_name"),
+			common.DeviceAttribute(device, "last_ip_used"),
+			formatTime(device.DeviceLastAuthenticatedDate))
+		labels = append(labels, label)
+		keys[label] = key
+	}
+	return labels, keys
+}
+
+// formatTime formats an optional timestamp for display
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
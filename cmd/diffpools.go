@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// diffpoolsCmd represents the diffpools command
+var diffpoolsCmd = &cobra.Command{
+	Use:   "diffpools",
+	Short: "Compare the configuration of two user pools",
+	Long: `The "diffpools" command compares the settings, schema attributes, app clients
+and groups of two Cognito User Pools and prints every difference.
+
+The first pool is selected from the profile chosen at startup. The second pool
+can live in another account or region.
+
+Run this command with "--target-profile=<profile>" to select the second pool from another AWS profile.
+Run this command with "--target-region=<region>" to select the second pool from another region, using the
+startup profile unless "--target-profile" is also set.
+Run this command with "--only-differences=false" to also print identical settings.
+
+App clients and groups are matched by name. Identifiers, timestamps and user
+counts are not compared.
+
+Example:
+  cognitousermanagement diffpools --target-profile=prod --target-region=eu-west-1`,
+	Run: func(cmd *cobra.Command, args []string) {
+		targetProfile, _ := cmd.Flags().GetString("target-profile")
+		targetRegion, _ := cmd.Flags().GetString("target-region")
+		onlyDifferences, _ := cmd.Flags().GetBool("only-differences")
+		ctx := cmd.Context()
+
+		// Without --target-profile the second pool is read with the credentials of the profile chosen at startup
+		targetConfig := config.AwsConfig.Copy()
+		if targetProfile != "" {
+			var err error
+			targetConfig, err = helpers.LoadAwsConfigFor(targetProfile, targetRegion)
+			if err != nil {
+				helpers.PrintFatalErrorLog(fmt.Sprintf("Error loading target AWS config: %v", err))
+			}
+		} else if targetRegion != "" {
+			targetConfig.Region = targetRegion
+		}
+
+		fmt.Println("Select the first user pool:")
+		source := selectUserPool(ctx)
+		fmt.Println("Select the second user pool:")
+		target := selectUserPoolFrom(ctx, targetConfig)
+
+		sourceValues, err := poolConfiguration(ctx, source, config.AwsConfig)
+		if err != nil {
+			log.Println("Error fetching user pool configuration:", err)
+			return
+		}
+		targetValues, err := poolConfiguration(ctx, target, targetConfig)
+		if err != nil {
+			log.Println("Error fetching user pool configuration:", err)
+			return
+		}
+
+		differences := helpers.PrintDiff(source, target, sourceValues, targetValues, onlyDifferences)
+		if differences > 0 {
+			helpers.PrintWarningErrorLog(fmt.Sprintf("%d settings differ between %s and %s", differences, source, target))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(diffpoolsCmd)
+	diffpoolsCmd.Flags().String("target-profile", "", "AWS profile used to select the second user pool")
+	diffpoolsCmd.Flags().String("target-region", "", "AWS region used to select the second user pool")
+	diffpoolsCmd.Flags().Bool("only-differences", true, "Only show settings that differ between the two pools")
+}
+
+// poolConfiguration fetches a snapshot of a user pool and flattens it into key/value pairs
+func poolConfiguration(ctx context.Context, userPool string, awsConfig aws.Config) (map[string]string, error) {
+	snapshot, err := common.BuildPoolSnapshot(userPool, awsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return snapshot.Flatten()
+}
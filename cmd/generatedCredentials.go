@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+)
+
+// passwordOptions controls how the passwords of new or changed users are chosen
+type passwordOptions struct {
+	policy    *types.PasswordPolicyType // Password policy of the pool
+	breaches  *helpers.BreachDatabase   // Local breach corpus passwords are checked against, nil to skip the check
+	generate  func() (string, error)    // Generates a password, nil to ask for one
+	stdin     string                    // Password read from stdin, empty to ask for one
+	generated *generatedCredentials     // Collects the generated passwords to reveal them once
+}
+
+// newPasswordOptions applies the --generate-password, --output, --password-stdin and --breach-db flags of a command
+// for the given pool policy. A password on stdin is read and checked right away.
+func newPasswordOptions(kind string, output string, fromStdin bool, breachDb string, policy *types.PasswordPolicyType) (passwordOptions, error) {
+	options := passwordOptions{policy: policy, breaches: openBreachDatabase(breachDb)}
+	if fromStdin {
+		options.stdin = readStdinPassword(policy, options.breaches)
+		return options, nil
+	}
+	if kind == "" {
+		return options, nil
+	}
+	generate, err := passwordGenerator(kind, policy, 0, 0)
+	if err != nil {
+		return options, err
+	}
+	options.generate = generate
+	if options.breaches != nil {
+		// Generated passwords are practically never breached, but are checked like any other password
+		options.generate = func() (string, error) {
+			for {
+				password, err := generate()
+				if err != nil || len(passwordProblems(password, policy, options.breaches)) == 0 {
+					return password, err
+				}
+			}
+		}
+	}
+	options.generated = &generatedCredentials{output: output}
+	return options, nil
+}
+
+// passwordGenerator returns a function that generates passwords or passphrases satisfying the policy
+// Parameters:
+//   - kind: "password" or "passphrase"
+//   - policy: Password policy of the pool
+//   - length: Length of generated passwords, 0 for the default
+//   - words: Number of words of generated passphrases, 0 for the default
+func passwordGenerator(kind string, policy *types.PasswordPolicyType, length int, words int) (func() (string, error), error) {
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case "password":
+		return func() (string, error) { return helpers.GeneratePassword(policy, length) }, nil
+	case "passphrase":
+		return func() (string, error) { return helpers.GeneratePassphrase(policy, words) }, nil
+	default:
+		return nil, fmt.Errorf("invalid --generate-password value %q, expected password or passphrase", kind)
+	}
+}
+
+// generatedCredentials collects generated passwords so they are revealed exactly once, after every user is processed
+type generatedCredentials struct {
+	output    string // File the credentials are written to, empty to print them
+	userNames []string
+	passwords []string
+}
+
+// add records the generated password of a user whose password was set successfully
+func (c *generatedCredentials) add(userName string, password string) {
+	c.userNames = append(c.userNames, userName)
+	c.passwords = append(c.passwords, password)
+}
+
+// reveal prints the collected credentials, or writes them as username,password rows to a file
+// that only the current user can read
+func (c *generatedCredentials) reveal() {
+	if c == nil || len(c.userNames) == 0 {
+		return
+	}
+
+	if c.output != "" {
+		f, err := os.OpenFile(c.output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("Error creating file: %v", err))
+		}
+		defer f.Close()
+
+		w := csv.NewWriter(f)
+		for i, userName := range c.userNames {
+			w.Write([]string{userName, c.passwords[i]})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("Error writing credentials: %v", err))
+		}
+		helpers.PrintSuccessLog(fmt.Sprintf("Generated credentials of %d users written to %s\n", len(c.userNames), c.output))
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tPASSWORD")
+	for i, userName := range c.userNames {
+		fmt.Fprintf(w, "%s\t%s\n", userName, c.passwords[i])
+	}
+	w.Flush()
+	helpers.PrintWarningErrorLog("Store these credentials now, they are not shown again")
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// genpasswordCmd represents the genpassword command
+var genpasswordCmd = &cobra.Command{
+	Use:   "genpassword",
+	Short: "Generate passwords or passphrases that satisfy the password policy of a user pool",
+	Long: `The "genpassword" command generates cryptographically random passwords, or diceware
+passphrases from the EFF large word list, that satisfy the password policy of a selected user pool.
+
+Run this command with "--passphrase=true" to generate passphrases instead of passwords
+Run this command with "--length=<n>" to set the length of passwords (default 16, at least the pool minimum and at most 256)
+Run this command with "--words=<n>" to set the number of words of passphrases (default 5)
+Run this command with "--count=<n>" to generate several passwords at once
+Run this command with "--output=<file>" to write the passwords to a file instead of the terminal
+
+Example:
+  cognitousermanagement genpassword --passphrase=true --count=3`,
+	Run: func(cmd *cobra.Command, args []string) {
+		passphrase, _ := cmd.Flags().GetBool("passphrase")
+		length, _ := cmd.Flags().GetInt("length")
+		words, _ := cmd.Flags().GetInt("words")
+		count, _ := cmd.Flags().GetInt("count")
+		output, _ := cmd.Flags().GetString("output")
+		if length < 1 || length > helpers.MaximumPasswordLength {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("invalid --length value %d, expected 1 to %d", length, helpers.MaximumPasswordLength))
+		}
+		if words < 1 {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("invalid --words value %d, expected at least 1", words))
+		}
+		if count < 1 {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("invalid --count value %d, expected at least 1", count))
+		}
+
+		userPool := selectUserPool(cmd.Context())
+		policy := fetchPasswordPolicy(cmd.Context(), userPool)
+
+		kind := "password"
+		if passphrase {
+			kind = "passphrase"
+		}
+		generate, err := passwordGenerator(kind, policy, length, words)
+		if err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+			if err != nil {
+				helpers.PrintFatalErrorLog(fmt.Sprintf("Error creating file: %v", err))
+			}
+			defer f.Close()
+			w = f
+		}
+
+		for i := 0; i < count; i++ {
+			password, err := generate()
+			if err != nil {
+				log.Println("Error generating password:", err)
+				return
+			}
+			fmt.Fprintln(w, password)
+		}
+		if output != "" {
+			helpers.PrintSuccessLog(fmt.Sprintf("%d %ss written to %s\n", count, kind, output))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(genpasswordCmd)
+	genpasswordCmd.Flags().Bool("passphrase", false, "Generate diceware passphrases instead of random passwords")
+	genpasswordCmd.Flags().Int("length", helpers.DefaultPasswordLength, "Length of generated passwords")
+	genpasswordCmd.Flags().Int("words", helpers.DefaultPassphraseWords, "Number of words of generated passphrases")
+	genpasswordCmd.Flags().Int("count", 1, "Number of passwords to generate")
+	genpasswordCmd.Flags().String("output", "", "Write the passwords to this file instead of the terminal")
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// groupCmd represents the group command
+var groupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Create, update, delete and describe groups in a Cognito User Pool",
+	Long: `The "group" command manages the lifecycle of groups in a Cognito User Pool.
+
+Available subcommands:
+  create     Create a new group
+  update     Change the description, precedence or IAM role of a group
+  delete     Delete one or more groups
+  describe   Show the settings and member count of a group
+  members    List the members of a group
+
+Example:
+  cognitousermanagement group create --name admins --precedence 1
+  cognitousermanagement group describe`,
+}
+
+// groupCreateCmd represents the group create command
+var groupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new group in a Cognito User Pool",
+	Run: func(cmd *cobra.Command, args []string) {
+		userPool := selectUserPool(cmd.Context())
+
+		name, _ := cmd.Flags().GetString("name")
+		if name == "" {
+			name = helpers.ReadInput("Please enter the group name: ")
+		}
+		if name == "" {
+			helpers.PrintFatalErrorLog("Group name cannot be empty.")
+		}
+
+		var settings common.GroupSettings
+		settings.Description, _ = cmd.Flags().GetString("description")
+		settings.RoleArn, _ = cmd.Flags().GetString("role-arn")
+		if cmd.Flags().Changed("precedence") {
+			precedence, _ := cmd.Flags().GetInt32("precedence")
+			settings.Precedence = aws.Int32(precedence)
+		}
+
+		err := common.CreateGroup(userPool, name, settings, config.AwsConfig)
+		if err != nil {
+			log.Println("Error creating group:", err)
+			return
+		}
+		helpers.PrintSuccessLog(fmt.Sprintf("Group %s created in pool %s\n", name, userPool))
+	},
+}
+
+// groupUpdateCmd represents the group update command
+var groupUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update the description, precedence or IAM role of a group",
+	Long: `Update a group in a Cognito User Pool.
+
+Only the settings passed as flags are changed, the others keep their current value.
+
+Run this command with "--clear-role=true" to remove the IAM role of the group.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userPool := selectUserPool(cmd.Context())
+		fmt.Println("Select the group to update:")
+		name := selectGroup(cmd.Context(), userPool)
+
+		// Start from the current settings so unchanged flags are preserved
+		group, err := common.GetGroup(userPool, name, config.AwsConfig)
+		if err != nil {
+			log.Println("Error fetching group:", err)
+			return
+		}
+		settings := common.GroupSettings{
+			Description: aws.ToString(group.Description),
+			Precedence:  group.Precedence,
+			RoleArn:     aws.ToString(group.RoleArn),
+		}
+
+		if cmd.Flags().Changed("description") {
+			settings.Description, _ = cmd.Flags().GetString("description")
+		}
+		if cmd.Flags().Changed("role-arn") {
+			settings.RoleArn, _ = cmd.Flags().GetString("role-arn")
+			if strings.TrimSpace(settings.RoleArn) == "" {
+				helpers.PrintFatalErrorLog("--role-arn cannot be empty, use --clear-role=true to remove the role of the group")
+			}
+		}
+		if clearRole, _ := cmd.Flags().GetBool("clear-role"); clearRole {
+			settings.RoleArn = ""
+		}
+		if cmd.Flags().Changed("precedence") {
+			precedence, _ := cmd.Flags().GetInt32("precedence")
+			settings.Precedence = aws.Int32(precedence)
+		}
+
+		err = common.UpdateGroup(userPool, name, settings, config.AwsConfig)
+		if err != nil {
+			log.Println("Error updating group:", err)
+			return
+		}
+		helpers.PrintSuccessLog(fmt.Sprintf("Group %s updated\n", name))
+	},
+}
+
+// groupDeleteCmd represents the group delete command
+var groupDeleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete one or more groups from a Cognito User Pool",
+	Long: `Delete groups from a Cognito User Pool.
+
+Members of a deleted group are not deleted, they only lose the membership.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		userPool := selectUserPool(cmd.Context())
+
+		groups, err := common.GetGroupsFromPool(userPool, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching groups:", err)
+			return
+		}
+		if len(groups) == 0 {
+			log.Println("No groups found in the selected user pool.")
+			return
+		}
+		fmt.Println("Select groups to delete:")
+		selectedGroups := helpers.CallMultiSelect(groups)
+		if len(selectedGroups) == 0 {
+			log.Println("No groups selected for deletion.")
+			return
+		}
+
+		for _, group := range selectedGroups {
+			if !helpers.Confirm(fmt.Sprintf("Are you sure you want to delete group %v", group)) {
+				helpers.PrintWarningErrorLog(fmt.Sprintf("Group %s deletion cancelled.\n", group))
+				continue
+			}
+			err = common.DeleteGroup(userPool, group, config.AwsConfig)
+			if err != nil {
+				log.Println("Error deleting group:", err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("Group %s deleted from pool %s\n", group, userPool))
+		}
+	},
+}
+
+// groupDescribeCmd represents the group describe command
+var groupDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show the settings and member count of a group",
+	Run: func(cmd *cobra.Command, args []string) {
+		userPool := selectUserPool(cmd.Context())
+		name := selectGroup(cmd.Context(), userPool)
+
+		group, err := common.GetGroup(userPool, name, config.AwsConfig)
+		if err != nil {
+			log.Println("Error fetching group:", err)
+			return
+		}
+		members, err := common.GetUsersInGroup(userPool, name, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching group members:", err)
+			return
+		}
+
+		precedence := "-"
+		if group.Precedence != nil {
+			precedence = fmt.Sprint(*group.Precedence)
+		}
+
+		fmt.Printf("Group:         %s\n", name)
+		fmt.Printf("Description:   %s\n", aws.ToString(group.Description))
+		fmt.Printf("Precedence:    %s\n", precedence)
+		fmt.Printf("IAM role ARN:  %s\n", aws.ToString(group.RoleArn))
+		fmt.Printf("Members:       %d\n", len(members))
+		fmt.Printf("Created:       %s\n", aws.ToTime(group.CreationDate).Format("2006-01-02 15:04:05"))
+		fmt.Printf("Last modified: %s\n", aws.ToTime(group.LastModifiedDate).Format("2006-01-02 15:04:05"))
+	},
+}
+
+// groupMembersCmd represents the group members command
+var groupMembersCmd = &cobra.Command{
+	Use:   "members [group]",
+	Short: "List the members of a group",
+	Long: `List every member of a group in a Cognito User Pool.
+
+Pass the group name as an argument, or omit it to select the group interactively.`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		userPool := selectUserPool(cmd.Context())
+
+		var group string
+		if len(args) == 1 {
+			group = args[0]
+		} else {
+			group = selectGroup(cmd.Context(), userPool)
+		}
+
+		members, err := common.GetUsersInGroup(userPool, group, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching group members:", err)
+			return
+		}
+		fmt.Printf("%v members found in the group %v\n", len(members), group)
+		for _, member := range members {
+			fmt.Println(member)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(groupCmd)
+	groupCmd.AddCommand(groupCreateCmd, groupUpdateCmd, groupDeleteCmd, groupDescribeCmd, groupMembersCmd)
+
+	for _, c := range []*cobra.Command{groupCreateCmd, groupUpdateCmd} {
+		c.Flags().String("description", "", "Description of the group")
+		c.Flags().Int32("precedence", 0, "Precedence of the group, lower values take priority")
+		c.Flags().String("role-arn", "", "IAM role ARN assumed by members of the group")
+	}
+	groupCreateCmd.Flags().String("name", "", "Name of the new group")
+	groupUpdateCmd.Flags().Bool("clear-role", false, "Remove the IAM role of the group")
+	groupUpdateCmd.MarkFlagsMutuallyExclusive("role-arn", "clear-role")
+}
@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// idpsCmd represents the idps command
+var idpsCmd = &cobra.Command{
+	Use:   "idps",
+	Short: "Inspect the identity providers of a Cognito User Pool",
+	Long: `The "idps" command shows the external identity providers configured on a Cognito User Pool.
+
+Available subcommands:
+  list       List every identity provider with its type
+  describe   Show the provider details and attribute mapping of a single identity provider
+
+Secrets in the provider details are masked.
+
+Example:
+  cognitousermanagement idps describe`,
+}
+
+// idpsListCmd represents the idps list command
+var idpsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the identity providers of a user pool",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tTYPE\tCREATED\tLAST MODIFIED")
+		count := 0
+		for provider, err := range common.ListIdentityProviders(userPool, config.AwsConfig, ctx, nil) {
+			if err != nil {
+				// Keep the rows read before the failed page
+				w.Flush()
+				log.Println("Error fetching identity providers:", err)
+				return
+			}
+			count++
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+				aws.ToString(provider.ProviderName),
+				provider.ProviderType,
+				formatTime(provider.CreationDate),
+				formatTime(provider.LastModifiedDate))
+		}
+		if count > 0 {
+			w.Flush()
+		}
+		fmt.Fprintf(os.Stderr, "%v identity providers found in the pool %v\n", count, userPool)
+	},
+}
+
+// idpsDescribeCmd represents the idps describe command
+var idpsDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show the details and attribute mapping of an identity provider",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+
+		providers, err := common.GetIdentityProviders(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching identity providers:", err)
+			return
+		}
+		if len(providers) == 0 {
+			log.Println("No identity providers found in the selected user pool.")
+			return
+		}
+		name := helpers.CallSingleSelect(providers)
+		if name == "" {
+			log.Println("No identity provider selected.")
+			return
+		}
+
+		provider, err := common.DescribeIdentityProvider(userPool, name, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error describing identity provider:", err)
+			return
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Name:\t%s\n", aws.ToString(provider.ProviderName))
+		fmt.Fprintf(w, "Type:\t%s\n", provider.ProviderType)
+		fmt.Fprintf(w, "Identifiers:\t%s\n", joinOrDash(provider.IdpIdentifiers))
+		fmt.Fprintf(w, "Created:\t%s\n", formatTime(provider.CreationDate))
+		fmt.Fprintf(w, "Last modified:\t%s\n", formatTime(provider.LastModifiedDate))
+		w.Flush()
+
+		fmt.Println("\nProvider details:")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, key := range sortedKeys(provider.ProviderDetails) {
+			value := provider.ProviderDetails[key]
+			if strings.Contains(strings.ToLower(key), "secret") {
+				value = "********"
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", key, value)
+		}
+		w.Flush()
+
+		fmt.Println("\nAttribute mapping (user pool ← provider):")
+		w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, key := range sortedKeys(provider.AttributeMapping) {
+			fmt.Fprintf(w, "  %s\t← %s\n", key, provider.AttributeMapping[key])
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(idpsCmd)
+	idpsCmd.AddCommand(idpsListCmd, idpsDescribeCmd)
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// linkuserCmd represents the linkuser command
+var linkuserCmd = &cobra.Command{
+	Use:   "linkuser",
+	Short: "Link a Google, SAML or other external identity to a native Cognito user",
+	Long: `The "linkuser" command links an identity from an external identity provider to an
+existing native user, so signing in through that provider signs in as the native user.
+
+It will:
+1. Let you select a user pool and the native user
+2. Let you select the identity provider
+3. Prompt for the subject of the user at the provider, unless "--subject" is passed
+
+The link must be created before the external identity signs in for the first time,
+otherwise Cognito has already created a separate federated user.
+
+Run this command with "--attribute=<name>" to match the external user on a provider
+attribute other than Cognito_Subject, e.g. a SAML NameID mapping.
+
+Example:
+  cognitousermanagement linkuser --subject=115869427319887432519`,
+	Run: func(cmd *cobra.Command, args []string) {
+		subject, _ := cmd.Flags().GetString("subject")
+		attribute, _ := cmd.Flags().GetString("attribute")
+		ctx := cmd.Context()
+
+		userPool := selectUserPool(ctx)
+		fmt.Println("Select the native user to link the identity to:")
+		user := selectUser(ctx, userPool)
+
+		providers, err := common.GetIdentityProviders(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching identity providers:", err)
+			return
+		}
+		if len(providers) == 0 {
+			log.Println("No identity providers found in the selected user pool.")
+			return
+		}
+		fmt.Println("Select the identity provider:")
+		provider := helpers.CallSingleSelect(providers)
+		if provider == "" {
+			log.Println("No identity provider selected.")
+			return
+		}
+
+		if subject == "" {
+			subject = helpers.ReadInput(fmt.Sprintf("Please enter the %s of the user at %s: ", attribute, provider))
+		}
+		if subject == "" {
+			helpers.PrintFatalErrorLog("Subject cannot be empty.")
+		}
+
+		err = common.LinkProviderForUser(userPool, user, provider, attribute, subject, config.AwsConfig)
+		if err != nil {
+			log.Println("Error linking identity:", err)
+			return
+		}
+		helpers.PrintSuccessLog(fmt.Sprintf("%s identity %s linked to user %s\n", provider, subject, user))
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(linkuserCmd)
+	linkuserCmd.Flags().String("subject", "", "Value of the provider attribute identifying the external user")
+	linkuserCmd.Flags().String("attribute", "Cognito_Subject", "Provider attribute used to match the external user")
+}
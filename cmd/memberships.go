@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// membershipsCmd represents the memberships command
+var membershipsCmd = &cobra.Command{
+	Use:   "memberships",
+	Short: "Report which users belong to which groups in a Cognito User Pool",
+	Long: `The "memberships" command builds a users × groups matrix for a Cognito User Pool,
+with one row per user and one column per group. Use it for access reviews.
+
+Run this command with "--format=csv|markdown" to choose the output format (default csv)
+Run this command with "--output=<file>" to write the report to a file instead of the terminal
+Only the report is written to stdout, prompts and progress go to stderr, so the output can be redirected.
+
+Example:
+  cognitousermanagement memberships --format=markdown --output=access-review.md`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		format = strings.ToLower(format)
+		if format != "csv" && format != "markdown" {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("invalid --format value %q, expected csv or markdown", format))
+		}
+		output, _ := cmd.Flags().GetString("output")
+
+		userPool := selectUserPool(cmd.Context())
+
+		matrix, err := common.BuildMembershipMatrix(userPool, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error building membership matrix:", err)
+			return
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				helpers.PrintFatalErrorLog(fmt.Sprintf("Error creating file: %v", err))
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if format == "markdown" {
+			err = matrix.WriteMarkdown(w)
+		} else {
+			err = matrix.WriteCSV(w)
+		}
+		if err != nil {
+			log.Println("Error writing membership report:", err)
+			return
+		}
+		if output != "" {
+			helpers.PrintSuccessLog(fmt.Sprintf("Membership report for %d users and %d groups written to %s\n", len(matrix.Users), len(matrix.Groups), output))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(membershipsCmd)
+	membershipsCmd.Flags().String("format", "csv", "Output format of the report: csv or markdown")
+	membershipsCmd.Flags().String("output", "", "Write the report to this file instead of the terminal")
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// mfaCmd represents the mfa command
+var mfaCmd = &cobra.Command{
+	Use:   "mfa",
+	Short: "View and change the MFA configuration of users and user pools",
+	Long: `The "mfa" command administers multi-factor authentication.
+
+Available subcommands:
+  show      Show the MFA configuration of a user
+  set       Enable, disable or prefer SMS, TOTP or email MFA for a user
+  disable   Disable every MFA factor for one or more users, e.g. after a lost device
+  pool      Show the MFA configuration of a user pool
+
+Example:
+  cognitousermanagement mfa set --totp=enable --preferred=totp
+  cognitousermanagement mfa disable`,
+}
+
+// mfaShowCmd represents the mfa show command
+var mfaShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the MFA configuration of a user",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+
+		output, err := common.AdminGetUser(user, userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching user:", err)
+			return
+		}
+
+		enabled := "none"
+		if len(output.UserMFASettingList) > 0 {
+			enabled = strings.Join(output.UserMFASettingList, ", ")
+		}
+		preferred := aws.ToString(output.PreferredMfaSetting)
+		if preferred == "" {
+			preferred = "none"
+		}
+
+		fmt.Printf("User:           %s\n", user)
+		fmt.Printf("Enabled MFA:    %s\n", enabled)
+		fmt.Printf("Preferred MFA:  %s\n", preferred)
+		for _, option := range output.MFAOptions {
+			fmt.Printf("Legacy option:  %s via %s\n", option.DeliveryMedium, aws.ToString(option.AttributeName))
+		}
+	},
+}
+
+// mfaSetCmd represents the mfa set command
+var mfaSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Enable, disable or prefer SMS, TOTP or email MFA for a user",
+	Long: `Change the MFA preference of a user.
+
+Pass "enable" or "disable" to "--sms", "--totp" or "--email" to change a factor, and
+"--preferred=sms|totp|email" to mark a factor as preferred. Factors that are not
+passed are left unchanged.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var preference common.MFAPreference
+		var err error
+		if preference.SMS, err = mfaToggleFlag(cmd, "sms"); err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+		if preference.SoftwareToken, err = mfaToggleFlag(cmd, "totp"); err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+		if preference.Email, err = mfaToggleFlag(cmd, "email"); err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+		preferred, _ := cmd.Flags().GetString("preferred")
+		// The toggle of the preferred factor, a factor cannot be both disabled and preferred
+		var preferredToggle *bool
+		switch strings.ToLower(preferred) {
+		case "":
+		case "sms":
+			preference.Preferred = "SMS"
+			preferredToggle = preference.SMS
+		case "totp":
+			preference.Preferred = "SOFTWARE_TOKEN"
+			preferredToggle = preference.SoftwareToken
+		case "email":
+			preference.Preferred = "EMAIL"
+			preferredToggle = preference.Email
+		default:
+			helpers.PrintFatalErrorLog(fmt.Sprintf("invalid --preferred value %q, expected sms, totp or email", preferred))
+		}
+		if preferredToggle != nil && !*preferredToggle {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("--preferred=%s conflicts with --%s=disable, a factor cannot be both disabled and preferred", strings.ToLower(preferred), strings.ToLower(preferred)))
+		}
+		if preference.SMS == nil && preference.SoftwareToken == nil && preference.Email == nil && preference.Preferred == "" {
+			helpers.PrintFatalErrorLog("Nothing to change, pass --sms, --totp, --email or --preferred")
+		}
+
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+
+		err = common.SetUserMFAPreference(userPool, user, preference, config.AwsConfig)
+		if err != nil {
+			log.Println("Error setting MFA preference:", err)
+			return
+		}
+		helpers.PrintSuccessLog(fmt.Sprintf("MFA preference of user %s updated\n", user))
+	},
+}
+
+// mfaDisableCmd represents the mfa disable command
+var mfaDisableCmd = &cobra.Command{
+	Use:   "disable",
+	Short: "Disable every MFA factor for one or more users",
+	Long: `Disable SMS, TOTP and email MFA and clear legacy SMS MFA options for the selected users.
+
+Use this for users who lost their device. If the user pool requires MFA, the users
+are asked to set up a factor again at their next sign-in.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+
+		users, err := common.GetUsersFromPool(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching users:", err)
+			return
+		}
+		if len(users) == 0 {
+			log.Println("No users found in the selected user pool.")
+			return
+		}
+		fmt.Println("Select users to disable MFA for:")
+		selectedUsers := helpers.CallMultiSelect(users)
+		if len(selectedUsers) == 0 {
+			log.Println("No users selected.")
+			return
+		}
+		if !helpers.Confirm(fmt.Sprintf("Disable MFA for %d user(s)?", len(selectedUsers))) {
+			helpers.PrintWarningErrorLog("MFA disable cancelled.")
+			return
+		}
+
+		disabled := common.MFAPreference{
+			SMS:           aws.Bool(false),
+			SoftwareToken: aws.Bool(false),
+			Email:         aws.Bool(false),
+		}
+		for _, user := range selectedUsers {
+			err := common.SetUserMFAPreference(userPool, user, disabled, config.AwsConfig)
+			if err != nil {
+				log.Println("Error disabling MFA:", err)
+				continue
+			}
+			err = common.ClearLegacyMFAOptions(userPool, user, config.AwsConfig)
+			if err != nil {
+				log.Println("Error disabling MFA:", err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("MFA disabled for user %s\n", user))
+		}
+	},
+}
+
+// mfaPoolCmd represents the mfa pool command
+var mfaPoolCmd = &cobra.Command{
+	Use:   "pool",
+	Short: "Show the MFA configuration of a user pool",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		userPool := selectUserPool(ctx)
+
+		pool, err := common.DescribeUserPool(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error describing user pool:", err)
+			return
+		}
+
+		fmt.Printf("User pool:            %s (%s)\n", aws.ToString(pool.Name), userPool)
+		fmt.Printf("MFA enforcement:      %s\n", pool.MfaConfiguration)
+		fmt.Printf("SMS message:          %s\n", aws.ToString(pool.SmsAuthenticationMessage))
+		if pool.SmsConfiguration != nil {
+			fmt.Printf("SMS IAM role:         %s\n", aws.ToString(pool.SmsConfiguration.SnsCallerArn))
+		}
+		if pool.SmsConfigurationFailure != nil {
+			helpers.PrintWarningErrorLog(fmt.Sprintf("SMS configuration failure: %s", aws.ToString(pool.SmsConfigurationFailure)))
+		}
+		if pool.UserPoolAddOns != nil {
+			fmt.Printf("Advanced security:    %s\n", pool.UserPoolAddOns.AdvancedSecurityMode)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mfaCmd)
+	mfaCmd.AddCommand(mfaShowCmd, mfaSetCmd, mfaDisableCmd, mfaPoolCmd)
+	mfaSetCmd.Flags().String("sms", "", "Enable or disable SMS MFA: enable or disable")
+	mfaSetCmd.Flags().String("totp", "", "Enable or disable TOTP MFA: enable or disable")
+	mfaSetCmd.Flags().String("email", "", "Enable or disable email MFA: enable or disable")
+	mfaSetCmd.Flags().String("preferred", "", "Preferred MFA factor: sms, totp or email")
+}
+
+// mfaToggleFlag reads an enable/disable flag, returning nil when the flag was not passed
+func mfaToggleFlag(cmd *cobra.Command, name string) (*bool, error) {
+	value, _ := cmd.Flags().GetString(name)
+	switch strings.ToLower(value) {
+	case "":
+		return nil, nil
+	case "enable":
+		return aws.Bool(true), nil
+	case "disable":
+		return aws.Bool(false), nil
+	default:
+		return nil, fmt.Errorf("invalid --%s value %q, expected enable or disable", name, value)
+	}
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+)
+
+// fetchPasswordPolicy reads the password policy of a user pool so passwords can be checked before they are sent
+// It exits when the pool cannot be described
+func fetchPasswordPolicy(ctx context.Context, userPool string) *types.PasswordPolicyType {
+	policy, err := common.GetPasswordPolicy(userPool, config.AwsConfig, ctx)
+	if err != nil {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("Error fetching password policy: %v", err))
+	}
+	return policy
+}
+
+// openBreachDatabase opens the local breach corpus given with --breach-db, nil when the flag is not set
+// It exits when the corpus cannot be opened
+func openBreachDatabase(path string) *helpers.BreachDatabase {
+	if path == "" {
+		return nil
+	}
+	breaches, err := helpers.OpenBreachDatabase(path)
+	if err != nil {
+		helpers.PrintFatalErrorLog(err.Error())
+	}
+	return breaches
+}
+
+// passwordProblems returns the password policy rules a password breaks, followed by a note
+// when the password is listed in the breach corpus
+// It exits when the breach corpus cannot be read
+func passwordProblems(password string, policy *types.PasswordPolicyType, breaches *helpers.BreachDatabase) []string {
+	problems := helpers.ValidatePassword(password, policy)
+	if breaches == nil {
+		return problems
+	}
+	count, err := breaches.Count(password)
+	if err != nil {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("Error checking breached passwords: %v", err))
+	}
+	if count > 0 {
+		problems = append(problems, fmt.Sprintf("has appeared %d times in known data breaches", count))
+	}
+	return problems
+}
+
+// readValidPassword asks for a password twice without echoing it until it satisfies the password policy of the pool
+// and is not listed in the breach corpus
+// It exits when password entry is cancelled
+func readValidPassword(prompt string, passwords passwordOptions) string {
+	for {
+		password, err := helpers.CallPasswordInput(prompt, passwords.policy)
+		if err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+		problems := passwordProblems(password, passwords.policy, passwords.breaches)
+		if len(problems) == 0 {
+			return password
+		}
+		helpers.PrintWarningErrorLog("The password " + strings.Join(problems, ", ") + ", choose another one")
+	}
+}
+
+// readStdinPassword reads a password piped on stdin and checks it against the password policy of the pool
+// and the breach corpus
+// It exits when stdin holds no password or the password is refused
+func readStdinPassword(policy *types.PasswordPolicyType, breaches *helpers.BreachDatabase) string {
+	password, err := helpers.ReadPasswordStdin()
+	if err != nil {
+		helpers.PrintFatalErrorLog(err.Error())
+	}
+	if problems := passwordProblems(password, policy, breaches); len(problems) > 0 {
+		helpers.PrintFatalErrorLog("The password is refused, it " + strings.Join(problems, ", "))
+	}
+	return password
+}
+
+// reportPasswordViolations checks the password of every row against the password policy of the pool
+// and the breach corpus, and prints the problems of each refused row under its number in the file.
+// Returns the number of refused rows.
+func reportPasswordViolations(rowNumbers []int, userNames []string, passwords []string, options passwordOptions) int {
+	invalid := 0
+	for i, password := range passwords {
+		problems := passwordProblems(strings.TrimSpace(password), options.policy, options.breaches)
+		if len(problems) == 0 {
+			continue
+		}
+		invalid++
+		helpers.PrintWarningErrorLog(fmt.Sprintf("Row %d (%s): password %s", rowNumbers[i], strings.TrimSpace(userNames[i]), strings.Join(problems, ", ")))
+	}
+	return invalid
+}
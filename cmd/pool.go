@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// poolCmd represents the pool command
+var poolCmd = &cobra.Command{
+	Use:   "pool",
+	Short: "Export, apply and generate infrastructure as code for user pool configurations",
+	Long: `The "pool" command manages user pool configuration as code.
+
+Available subcommands:
+  export   Write the settings, schema, groups, resource servers, identity providers and app clients of a pool as YAML
+  apply    Create a new pool from a YAML document or update an existing one
+  codegen  Generate Terraform or CloudFormation definitions of a live pool
+
+The keys of the document follow the Cognito API reference (CreateUserPool,
+CreateGroup, CreateResourceServer, CreateIdentityProvider and CreateUserPoolClient).
+Identity provider secrets are exported as ${VARIABLE} placeholders, which are
+read from the environment when the document is applied.
+
+Example:
+  cognitousermanagement pool export --output=tenant.yaml
+  cognitousermanagement pool apply --file=tenant.yaml --name=tenant-acme
+  cognitousermanagement pool codegen --format=terraform --output=cognito.tf`,
+}
+
+// poolExportCmd represents the pool export command
+var poolExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the configuration of a user pool as YAML",
+	Long: `Export the configuration of a selected user pool as a YAML document.
+
+Run this command with "--output=<file>" to write the document to a file instead of the terminal
+
+Example:
+  cognitousermanagement pool export --output=tenant.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		userPool := selectUserPool(cmd.Context())
+
+		doc, err := common.ExportPoolDocument(userPool, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error exporting user pool:", err)
+			return
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				helpers.PrintFatalErrorLog(fmt.Sprintf("Error creating file: %v", err))
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if err := doc.WriteYAML(w); err != nil {
+			log.Println("Error writing pool document:", err)
+			return
+		}
+		if output != "" {
+			helpers.PrintSuccessLog(fmt.Sprintf("Configuration of pool %s written to %s\n", userPool, output))
+		}
+	},
+}
+
+// poolApplyCmd represents the pool apply command
+var poolApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update a user pool from a YAML document",
+	Long: `Create a new user pool from a YAML document, or update an existing pool to match it.
+
+A preview of every setting that changes is shown before anything is applied.
+Groups, resource servers, identity providers and app clients are matched by name
+and created or updated. Nothing missing from the document is deleted.
+
+Run this command with "--file=<file>" to read the document
+Run this command with "--pool-id=<id>" to update an existing pool instead of creating a new one
+Run this command with "--name=<name>" to override the pool name of the document
+
+Example:
+  cognitousermanagement pool apply --file=tenant.yaml --name=tenant-acme
+  cognitousermanagement pool apply --file=tenant.yaml --pool-id=eu-west-1_AbCdEf123`,
+	Run: func(cmd *cobra.Command, args []string) {
+		file, _ := cmd.Flags().GetString("file")
+		userPool, _ := cmd.Flags().GetString("pool-id")
+		name, _ := cmd.Flags().GetString("name")
+		ctx := cmd.Context()
+
+		if file == "" {
+			helpers.PrintFatalErrorLog("A pool document is required, pass it with --file")
+		}
+		f, err := os.Open(file)
+		if err != nil {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("Error opening file: %v", err))
+		}
+		defer f.Close()
+
+		doc, err := common.ReadPoolDocument(f)
+		if err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+		if name != "" {
+			doc.Pool.PoolName = aws.String(name)
+		}
+
+		desired, err := doc.Flatten()
+		if err != nil {
+			log.Println("Error reading pool document:", err)
+			return
+		}
+		current := map[string]string{}
+		if userPool != "" {
+			existing, err := common.ExportPoolDocument(userPool, config.AwsConfig, ctx)
+			if err != nil {
+				log.Println("Error exporting user pool:", err)
+				return
+			}
+			if current, err = existing.Flatten(); err != nil {
+				log.Println("Error exporting user pool:", err)
+				return
+			}
+		}
+
+		// Check the secrets before the preview so a missing variable fails before any confirmation
+		if err := doc.ResolveSecrets(os.LookupEnv); err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+
+		target := "new pool"
+		if userPool != "" {
+			target = userPool
+		}
+		if helpers.PrintDiff(target, "document", current, desired, true) == 0 {
+			return
+		}
+		if !helpers.Confirm(fmt.Sprintf("Apply these changes to %s?", target)) {
+			helpers.PrintWarningErrorLog("Apply cancelled")
+			return
+		}
+
+		userPool, err = common.ApplyPoolDocument(doc, userPool, config.AwsConfig, ctx, func(change string) {
+			fmt.Println(change)
+		})
+		if err != nil {
+			log.Println("Error applying pool document:", err)
+			return
+		}
+		helpers.PrintSuccessLog(fmt.Sprintf("Pool document %s applied to pool %s\n", file, userPool))
+	},
+}
+
+// poolCodegenCmd represents the pool codegen command
+var poolCodegenCmd = &cobra.Command{
+	Use:   "codegen",
+	Short: "Generate Terraform or CloudFormation definitions of a user pool",
+	Long: `Generate infrastructure as code for a selected user pool, its groups, resource servers,
+identity providers and app clients, so hand-made pools can be brought under IaC.
+
+Terraform output contains aws_cognito_* resources with import blocks for the live resources.
+CloudFormation output retains every resource on deletion and lists the resources to import
+in a comment at the top of the template. Identity provider secrets become sensitive
+variables or NoEcho parameters.
+
+Run this command with "--format=terraform|cloudformation" to choose the output format (default terraform)
+Run this command with "--output=<file>" to write the definitions to a file instead of the terminal
+
+Example:
+  cognitousermanagement pool codegen --format=terraform --output=cognito.tf
+  cognitousermanagement pool codegen --format=cloudformation --output=cognito.yaml`,
+	Run: func(cmd *cobra.Command, args []string) {
+		format, _ := cmd.Flags().GetString("format")
+		format = strings.ToLower(format)
+		if format != "terraform" && format != "cloudformation" {
+			helpers.PrintFatalErrorLog(fmt.Sprintf("invalid --format value %q, expected terraform or cloudformation", format))
+		}
+		output, _ := cmd.Flags().GetString("output")
+		ctx := cmd.Context()
+
+		userPool := selectUserPool(ctx)
+
+		doc, err := common.ExportPoolDocument(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error exporting user pool:", err)
+			return
+		}
+		clients, err := common.GetPoolClients(userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching app clients:", err)
+			return
+		}
+		ids := common.PoolResourceIds{UserPoolId: userPool, ClientIds: make(map[string]string)}
+		for _, client := range clients {
+			ids.ClientIds[aws.ToString(client.ClientName)] = aws.ToString(client.ClientId)
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				helpers.PrintFatalErrorLog(fmt.Sprintf("Error creating file: %v", err))
+			}
+			defer f.Close()
+			w = f
+		}
+
+		if format == "cloudformation" {
+			err = doc.WriteCloudFormation(w, ids)
+		} else {
+			err = doc.WriteTerraform(w, ids)
+		}
+		if err != nil {
+			log.Println("Error writing definitions:", err)
+			return
+		}
+		if output != "" {
+			helpers.PrintSuccessLog(fmt.Sprintf("%s definitions of pool %s written to %s\n", format, userPool, output))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(poolCmd)
+	poolCmd.AddCommand(poolExportCmd, poolApplyCmd, poolCodegenCmd)
+
+	poolExportCmd.Flags().String("output", "", "Write the document to this file instead of the terminal")
+	poolApplyCmd.Flags().String("file", "", "YAML pool document to apply")
+	poolApplyCmd.Flags().String("pool-id", "", "ID of an existing pool to update instead of creating a new one")
+	poolApplyCmd.Flags().String("name", "", "Pool name to use instead of the one in the document")
+	poolCodegenCmd.Flags().String("format", "terraform", "Output format: terraform or cloudformation")
+	poolCodegenCmd.Flags().String("output", "", "Write the definitions to this file instead of the terminal")
+}
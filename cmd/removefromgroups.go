@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// removefromgroupsCmd represents the removefromgroups command
+var removefromgroupsCmd = &cobra.Command{
+	Use:   "removefromgroups",
+	Short: "Remove a user from one or more groups in a Cognito User Pool",
+	Long: `The "removefromgroups" command allows you to select a user from a Cognito User Pool
+and remove them from one or more of the groups they currently belong to.
+
+Run this command with "--all=true" to remove the user from every group, e.g. during offboarding.
+
+Example:
+  cognitousermanagement removefromgroups
+  cognitousermanagement removefromgroups --all=true`,
+	Run: func(cmd *cobra.Command, args []string) {
+		all, _ := cmd.Flags().GetBool("all")
+
+		// Get selected user pool from available pools
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching user pools:", err)
+			return
+		}
+		userPool := helpers.CallSingleSelect(userPools)
+		if userPool == "" {
+			helpers.PrintFatalErrorLog("No user pool selected")
+		}
+
+		users, err := common.GetUsersFromPool(userPool, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching users:", err)
+			return
+		}
+		if len(users) == 0 {
+			log.Println("No users found in the selected user pool.")
+			return
+		}
+		user := helpers.CallSingleSelect(users)
+		if user == "" {
+			log.Println("No user selected.")
+			return
+		}
+
+		// Only offer the groups the user is currently a member of
+		groups, err := common.GetGroupsForUser(userPool, user, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching groups for user:", err)
+			return
+		}
+		if len(groups) == 0 {
+			log.Printf("User %s is not a member of any group.\n", user)
+			return
+		}
+
+		selectedGroups := groups
+		if !all {
+			fmt.Println("Select groups to remove the user from:")
+			selectedGroups = helpers.CallMultiSelect(groups)
+			if len(selectedGroups) == 0 {
+				log.Println("No groups selected.")
+				return
+			}
+		} else if !helpers.Confirm(fmt.Sprintf("Remove user %s from all %d group(s)?", user, len(groups))) {
+			helpers.PrintWarningErrorLog("Group removal cancelled.")
+			return
+		}
+
+		for _, group := range selectedGroups {
+			err = common.RemoveUserFromGroup(userPool, user, group, config.AwsConfig)
+			if err != nil {
+				log.Println("Error removing user from group:", err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("User %s removed from group %s\n", user, group))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(removefromgroupsCmd)
+	removefromgroupsCmd.Flags().Bool("all", false, "Remove the user from every group they belong to")
+}
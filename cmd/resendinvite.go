@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// resendinviteCmd represents the resendinvite command
+var resendinviteCmd = &cobra.Command{
+	Use:   "resendinvite",
+	Short: "Resend the invitation message to users who have not signed in yet",
+	Long: `The "resendinvite" command resends the welcome message to users in the
+FORCE_CHANGE_PASSWORD status. Cognito generates a new temporary password and
+restarts its expiry period.
+
+Run this command with "--invite=email|sms|both" to choose the delivery channel (default email)
+Run this command with "--all=true" to resend to every user stuck in FORCE_CHANGE_PASSWORD
+
+Example:
+  cognitousermanagement resendinvite --invite=both
+  cognitousermanagement resendinvite --all=true`,
+	Run: func(cmd *cobra.Command, args []string) {
+		inviteFlag, _ := cmd.Flags().GetString("invite")
+		invite, err := inviteOptionsFromFlag(inviteFlag)
+		if err != nil {
+			helpers.PrintFatalErrorLog(err.Error())
+		}
+		if len(invite.DeliveryMediums) == 0 {
+			helpers.PrintFatalErrorLog("An invitation can only be resent via email, sms or both")
+		}
+		all, _ := cmd.Flags().GetBool("all")
+
+		// Get selected user pool from available pools
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching user pools:", err)
+			return
+		}
+		userPool := helpers.CallSingleSelect(userPools)
+		if userPool == "" {
+			helpers.PrintFatalErrorLog("No user pool selected")
+		}
+
+		// Only users that never completed their first sign-in can be re-invited
+		users, err := common.GetUsersByStatus(userPool, "FORCE_CHANGE_PASSWORD", config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching users:", err)
+			return
+		}
+		if len(users) == 0 {
+			log.Println("No users waiting for their first sign-in found in the selected user pool.")
+			return
+		}
+
+		selectedUsers := users
+		if !all {
+			fmt.Println("Select users to resend the invitation to:")
+			selectedUsers = helpers.CallMultiSelect(users)
+			if len(selectedUsers) == 0 {
+				log.Println("No users selected.")
+				return
+			}
+		}
+
+		if !helpers.Confirm(fmt.Sprintf("Resend the invitation to %d user(s)?", len(selectedUsers))) {
+			helpers.PrintWarningErrorLog("Resend cancelled.")
+			return
+		}
+
+		for _, user := range selectedUsers {
+			err := common.ResendInvitation(userPool, user, invite.DeliveryMediums, config.AwsConfig)
+			if err != nil {
+				log.Printf("Error resending invitation to user %s: %v\n", user, err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("Invitation resent to user %s\n", user))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resendinviteCmd)
+	resendinviteCmd.Flags().String("invite", "email", "Deliver the invitation message via email, sms or both")
+	resendinviteCmd.Flags().Bool("all", false, "Resend to every user in the FORCE_CHANGE_PASSWORD status without selecting")
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// resetpasswordCmd represents the resetpassword command
+var resetpasswordCmd = &cobra.Command{
+	Use:   "resetpassword",
+	Short: "Reset the password of one or more Cognito users",
+	Long: `The "resetpassword" command triggers an administrative password reset for the selected users.
+
+Cognito sends each user a confirmation code through their verified email address
+or phone number. The users must set a new password with that code before they can
+sign in again.
+
+Example:
+  cognitousermanagement resetpassword`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Get selected user pool from available pools
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching user pools:", err)
+			return
+		}
+		userPool := helpers.CallSingleSelect(userPools)
+		if userPool == "" {
+			helpers.PrintFatalErrorLog("No user pool selected")
+		}
+
+		// Fetch all users from the selected pool
+		users, err := common.GetUsersFromPool(userPool, config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching users:", err)
+			return
+		}
+		if len(users) == 0 {
+			log.Println("No users found in the selected user pool.")
+			return
+		}
+		fmt.Println("Select users to reset the password for:")
+		selectedUsers := helpers.CallMultiSelect(users)
+		if len(selectedUsers) == 0 {
+			log.Println("No users selected.")
+			return
+		}
+
+		if !helpers.Confirm(fmt.Sprintf("Reset the password of %d user(s)?", len(selectedUsers))) {
+			helpers.PrintWarningErrorLog("Password reset cancelled.")
+			return
+		}
+
+		for _, user := range selectedUsers {
+			err := common.ResetUserPassword(userPool, user, config.AwsConfig)
+			if err != nil {
+				log.Println("Error resetting password:", err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("Password reset for user %s, a code was sent to the user\n", user))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resetpasswordCmd)
+}
@@ -4,9 +4,13 @@ Copyright © 2025 Ramal Abeysekera ramal.abeysekera@hotmail.com
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
 	"github.com/spf13/cobra"
 )
 
@@ -34,7 +38,8 @@ deleting, and listing users, as well as managing groups and user attributes.
 It is built to streamline user management tasks for developers and administrators 
 working with AWS Cognito.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		fmt.Print(banner)
+		// The banner goes to stderr so reports written to stdout can be redirected
+		fmt.Fprint(os.Stderr, banner)
 	},
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
@@ -43,8 +48,28 @@ working with AWS Cognito.`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Ctrl+C or SIGTERM cancels the context passed to the commands, so a running listing or loop over users
+// stops cleanly. When nothing is running that would notice the cancellation, e.g. at a prompt, the process
+// exits right away. A second Ctrl+C always exits.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		if !helpers.CancellableRunning() {
+			fmt.Fprintln(os.Stderr, "\nInterrupted")
+			os.Exit(130)
+		}
+		fmt.Fprintln(os.Stderr, "\nStopping, press Ctrl+C again to exit immediately")
+		cancel()
+		<-signals
+		os.Exit(130)
+	}()
+
+	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
 		os.Exit(1)
 	}
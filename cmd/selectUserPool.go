@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+)
+
+// selectUserPool lets the user pick one of the user pools in the account
+// It exits when the pools cannot be listed or none is selected
+func selectUserPool(ctx context.Context) string {
+	return selectUserPoolFrom(ctx, config.AwsConfig)
+}
+
+// selectUserPoolFrom lets the user pick one of the user pools visible with the given AWS configuration
+func selectUserPoolFrom(ctx context.Context, awsConfig aws.Config) string {
+	userPools, err := common.GetAllPools(awsConfig, ctx)
+	if err != nil {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("Error fetching user pools: %v", err))
+	}
+	if len(userPools) == 0 {
+		helpers.PrintFatalErrorLog("No user pools found")
+	}
+	userPool := helpers.CallSingleSelect(userPools)
+	if userPool == "" {
+		helpers.PrintFatalErrorLog("No user pool selected")
+	}
+	return userPool
+}
+
+// selectGroup lets the user pick one of the groups of a user pool
+// It exits when the groups cannot be listed, the pool has no groups or no group is selected
+func selectGroup(ctx context.Context, userPool string) string {
+	groups, err := common.GetGroupsFromPool(userPool, config.AwsConfig, ctx)
+	if err != nil {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("Error fetching groups: %v", err))
+	}
+	if len(groups) == 0 {
+		helpers.PrintFatalErrorLog("No groups found in the selected user pool.")
+	}
+	group := helpers.CallSingleSelect(groups)
+	if group == "" {
+		helpers.PrintFatalErrorLog("No group selected")
+	}
+	return group
+}
+
+// selectUser lets the user pick one of the users of a user pool
+// It exits when the users cannot be listed, the pool has no users or no user is selected
+func selectUser(ctx context.Context, userPool string) string {
+	users, err := common.GetUsersFromPool(userPool, config.AwsConfig, ctx)
+	if err != nil {
+		helpers.PrintFatalErrorLog(fmt.Sprintf("Error fetching users: %v", err))
+	}
+	if len(users) == 0 {
+		helpers.PrintFatalErrorLog("No users found in the selected user pool.")
+	}
+	user := helpers.CallSingleSelect(users)
+	if user == "" {
+		helpers.PrintFatalErrorLog("No user selected")
+	}
+	return user
+}
@@ -4,12 +4,8 @@ Copyright © 2025 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"bufio"
-	"context"
 	"fmt"
 	"log"
-	"os"
-	"strings"
 
 	"github.com/ramalabeysekera/cognito-user-management/config"
 	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
@@ -20,28 +16,65 @@ import (
 // setpasswordCmd represents the setpassword command
 var setpasswordCmd = &cobra.Command{
 	Use:   "setpassword",
-	Short: "Set a permanent password for a Cognito user",
-	Long: `The setpassword command allows you to set a permanent password for a user in an AWS Cognito user pool.
+	Short: "Set a permanent or temporary password for Cognito users",
+	Long: `The setpassword command allows you to set a password for one or more users in an AWS Cognito user pool.
 	
 It will:
 1. Let you select a user pool from available pools
 2. Display list of users in the selected pool
-3. Allow you to select a user
-4. Prompt for a new password
-5. Set the permanent password for the selected user`,
+3. Allow you to select one or more users
+4. Prompt for a new password and check it against the password policy of the pool
+5. Set the password for every selected user
+
+Run this command with "--temporary=true" to set a temporary password instead.
+The users are then forced to choose a new password at their next sign-in.
+
+Run this command with "--generate-password" or "--generate-password=passphrase" to generate a separate
+password for every selected user instead of entering one.
+Run this command with "--output=<file>" to write generated credentials to a file instead of showing them once.
+Run this command with "--password-stdin=true" to read the password from stdin, e.g. piped from a secrets manager CLI.
+Run this command with "--breach-db=<path>" to refuse passwords listed in a local Have I Been Pwned corpus,
+either a directory of range files or a hash file sorted by hash.
+Run this command with "--bulk=true" to set the passwords of many users from a CSV file of username,password rows.
+An optional third column of true or false overrides whether the password of that row is permanent.
+
+The password is entered twice without being shown on the terminal.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
+		// Check if temporary flag is set
+		temporary, _ := cmd.Flags().GetBool("temporary")
+
 		// Get selected user pool from available pools by displaying interactive selection
 		// Get selected user pool from available pools
-		userPools, err := common.GetAllPools(config.AwsConfig)
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
 		if err != nil {
 			log.Println("Error fetching user pools:", err)
 			return
 		}
 		userPool := helpers.CallSingleSelect(userPools)
 		if userPool != "" {
+			// Set the passwords listed in a CSV file instead of selecting users
+			bulk, _ := cmd.Flags().GetBool("bulk")
+			if bulk {
+				rows, err := readPasswordRows(helpers.PromptFilePath(), !temporary)
+				if err != nil {
+					helpers.PrintFatalErrorLog(err.Error())
+				}
+				if len(rows) == 0 {
+					log.Println("No username,password rows found in the file.")
+					return
+				}
+				breachDb, _ := cmd.Flags().GetString("breach-db")
+				passwords, err := newPasswordOptions("", "", false, breachDb, fetchPasswordPolicy(cmd.Context(), userPool))
+				if err != nil {
+					helpers.PrintFatalErrorLog(err.Error())
+				}
+				setPasswordRows(cmd.Context(), userPool, rows, passwords)
+				return
+			}
+
 			// Fetch all users from the selected pool
-			users, err := common.GetUsersFromPool(userPool, config.AwsConfig)
+			users, err := common.GetUsersFromPool(userPool, config.AwsConfig, cmd.Context())
 			if err != nil {
 				log.Println("Error fetching users:", err)
 				return
@@ -51,38 +84,85 @@ It will:
 				log.Println("No users found in the selected user pool.")
 				return
 			}
-			fmt.Println("Select a user to set a permanent password:")
+			if temporary {
+				fmt.Println("Select users to set a temporary password:")
+			} else {
+				fmt.Println("Select users to set a permanent password:")
+			}
 			// Display interactive user selection prompt
 
-			user := helpers.CallSingleSelect(users)
+			selectedUsers := helpers.CallMultiSelect(users)
+			if len(selectedUsers) == 0 {
+				log.Println("No users selected.")
+				return
+			}
 
-			// Get the password from the user via stdin
-			reader := bufio.NewReader(os.Stdin)
-			fmt.Print("Please enter the new password: ")
-			password, err := reader.ReadString('\n')
+			// Generate a password per user, or read one from stdin and check it against the password policy of the pool
+			generateFlag, _ := cmd.Flags().GetString("generate-password")
+			output, _ := cmd.Flags().GetString("output")
+			fromStdin, _ := cmd.Flags().GetBool("password-stdin")
+			breachDb, _ := cmd.Flags().GetString("breach-db")
+			passwords, err := newPasswordOptions(generateFlag, output, fromStdin, breachDb, fetchPasswordPolicy(cmd.Context(), userPool))
 			if err != nil {
-				log.Println("Error reading password:", err)
-				return
+				helpers.PrintFatalErrorLog(err.Error())
+			}
+			password := passwords.stdin
+			if passwords.generate == nil && password == "" {
+				password = readValidPassword("Please enter the new password", passwords)
 			}
 
-			// Remove the newline character from the password input
-			password = strings.TrimSpace(password)
+			ctx := cmd.Context()
 
-			// Create a context for the operation
-			ctx := context.Background()
+			// Ctrl+C stops before the next user
+			done := helpers.TrackCancellable()
+			for i, user := range selectedUsers {
+				if ctx.Err() != nil {
+					helpers.PrintWarningErrorLog(fmt.Sprintf("Cancelled, the password of %d of %d users was not set", len(selectedUsers)-i, len(selectedUsers)))
+					break
+				}
+				if passwords.generate != nil {
+					password, err = passwords.generate()
+					if err != nil {
+						log.Println("Error generating password:", err)
+						break
+					}
+				}
 
-			// Call AWS Cognito API to set the permanent password for the user
-			_, err = common.SetPermanentPassword(userPool, user, password, config.AwsConfig, ctx)
+				// Call AWS Cognito API to set the password for the user
+				_, err = common.SetUserPassword(userPool, user, password, !temporary, config.AwsConfig, ctx)
 
-			if err != nil {
-				log.Println("Error setting password:", err)
-				return
+				if err != nil {
+					log.Printf("Error setting password for user %s: %v\n", user, err)
+					continue
+				}
+				if passwords.generate != nil {
+					passwords.generated.add(user, password)
+				}
+				if temporary {
+					helpers.PrintSuccessLog(fmt.Sprintf("Temporary password set for user %s, password change required at next login\n", user))
+				} else {
+					helpers.PrintSuccessLog(fmt.Sprintf("Password set successfully for user %s\n", user))
+				}
 			}
-			helpers.PrintSuccessLog(fmt.Sprintf("Password set successfully for user %s\n", user))
+
+			done()
+
+			// Show the generated passwords once every user is processed
+			passwords.generated.reveal()
 		}
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(setpasswordCmd)
+	setpasswordCmd.Flags().Bool("temporary", false, "Set a temporary password that must be changed at next login")
+	setpasswordCmd.Flags().String("generate-password", "", "Generate a password or passphrase for every user instead of reading one")
+	setpasswordCmd.Flags().Lookup("generate-password").NoOptDefVal = "password"
+	setpasswordCmd.Flags().String("output", "", "Write generated credentials to this file instead of showing them")
+	setpasswordCmd.Flags().Bool("password-stdin", false, "Read the password from stdin")
+	setpasswordCmd.Flags().String("breach-db", "", "Refuse passwords listed in this local breach corpus, a range file directory or sorted hash file")
+	setpasswordCmd.Flags().Bool("bulk", false, "Set the passwords of users listed in a CSV file of username,password[,permanent] rows")
+	setpasswordCmd.MarkFlagsMutuallyExclusive("generate-password", "password-stdin")
+	setpasswordCmd.MarkFlagsMutuallyExclusive("bulk", "generate-password")
+	setpasswordCmd.MarkFlagsMutuallyExclusive("bulk", "password-stdin")
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// signoutCmd represents the signout command
+var signoutCmd = &cobra.Command{
+	Use:   "signout",
+	Short: "Sign users out globally and revoke their refresh tokens",
+	Long: `The "signout" command runs a global sign-out for one or more users in a Cognito User Pool.
+All refresh tokens issued to the users are invalidated, so they have to sign in again
+once their current access and ID tokens expire.
+
+Run this command with "--group=true" to sign out every member of a selected group.
+
+Example:
+  cognitousermanagement signout
+  cognitousermanagement signout --group=true`,
+	Run: func(cmd *cobra.Command, args []string) {
+		// Get selected user pool from available pools
+		userPools, err := common.GetAllPools(config.AwsConfig, cmd.Context())
+		if err != nil {
+			log.Println("Error fetching user pools:", err)
+			return
+		}
+		userPool := helpers.CallSingleSelect(userPools)
+		if userPool == "" {
+			helpers.PrintFatalErrorLog("No user pool selected")
+		}
+
+		byGroup, _ := cmd.Flags().GetBool("group")
+
+		var users []string
+		if byGroup {
+			groups, err := common.GetGroupsFromPool(userPool, config.AwsConfig, cmd.Context())
+			if err != nil {
+				log.Println("Error fetching groups:", err)
+				return
+			}
+			if len(groups) == 0 {
+				log.Println("No groups found in the selected user pool.")
+				return
+			}
+			fmt.Println("Select the group whose members should be signed out:")
+			group := helpers.CallSingleSelect(groups)
+			if group == "" {
+				log.Println("No group selected.")
+				return
+			}
+
+			users, err = common.GetUsersInGroup(userPool, group, config.AwsConfig, cmd.Context())
+			if err != nil {
+				log.Println("Error fetching group members:", err)
+				return
+			}
+			if len(users) == 0 {
+				log.Printf("Group %s has no members.\n", group)
+				return
+			}
+		} else {
+			poolUsers, err := common.GetUsersFromPool(userPool, config.AwsConfig, cmd.Context())
+			if err != nil {
+				log.Println("Error fetching users:", err)
+				return
+			}
+			if len(poolUsers) == 0 {
+				log.Println("No users found in the selected user pool.")
+				return
+			}
+			fmt.Println("Select users to sign out:")
+			users = helpers.CallMultiSelect(poolUsers)
+			if len(users) == 0 {
+				log.Println("No users selected.")
+				return
+			}
+		}
+
+		if !helpers.Confirm(fmt.Sprintf("Sign out %d user(s) from all devices?", len(users))) {
+			helpers.PrintWarningErrorLog("Sign-out cancelled.")
+			return
+		}
+
+		failed := 0
+		for _, user := range users {
+			err := common.GlobalSignOut(userPool, user, config.AwsConfig)
+			if err != nil {
+				log.Println("Error signing out user:", err)
+				failed++
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("User %s signed out from all devices\n", user))
+		}
+		if failed > 0 {
+			helpers.PrintWarningErrorLog(fmt.Sprintf("%d of %d sign-outs failed", failed, len(users)))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(signoutCmd)
+	signoutCmd.Flags().Bool("group", false, "Sign out every member of a selected group")
+}
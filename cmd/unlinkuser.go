@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/ramalabeysekera/cognito-user-management/config"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/common"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+	"github.com/spf13/cobra"
+)
+
+// unlinkuserCmd represents the unlinkuser command
+var unlinkuserCmd = &cobra.Command{
+	Use:   "unlinkuser",
+	Short: "Unlink external identities from a Cognito user",
+	Long: `The "unlinkuser" command removes links between a Cognito user and identities from
+external identity providers.
+
+The identities linked to the selected user are read from its "identities" attribute.
+An unlinked identity can no longer sign in until it is linked again.
+
+Example:
+  cognitousermanagement unlinkuser`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+
+		userPool := selectUserPool(ctx)
+		user := selectUser(ctx, userPool)
+
+		output, err := common.AdminGetUser(user, userPool, config.AwsConfig, ctx)
+		if err != nil {
+			log.Println("Error fetching user:", err)
+			return
+		}
+		identities, err := common.ParseIdentities(output.UserAttributes)
+		if err != nil {
+			log.Println("Error reading linked identities:", err)
+			return
+		}
+		if len(identities) == 0 {
+			log.Printf("User %s has no linked identities.\n", user)
+			return
+		}
+
+		var labels []string
+		byLabel := make(map[string]common.LinkedIdentity)
+		for _, identity := range identities {
+			label := fmt.Sprintf("%s: %s", identity.ProviderName, identity.UserId)
+			labels = append(labels, label)
+			byLabel[label] = identity
+		}
+		fmt.Println("Select identities to unlink:")
+		selected := helpers.CallMultiSelect(labels)
+		if len(selected) == 0 {
+			log.Println("No identities selected.")
+			return
+		}
+		if !helpers.Confirm(fmt.Sprintf("Unlink %d identity(ies) from user %s?", len(selected), user)) {
+			helpers.PrintWarningErrorLog("Unlink cancelled.")
+			return
+		}
+
+		for _, label := range selected {
+			identity := byLabel[label]
+			err := common.DisableProviderForUser(userPool, identity.ProviderName, identity.UserId, config.AwsConfig)
+			if err != nil {
+				log.Println("Error unlinking identity:", err)
+				continue
+			}
+			helpers.PrintSuccessLog(fmt.Sprintf("%s identity %s unlinked from user %s\n", identity.ProviderName, identity.UserId, user))
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(unlinkuserCmd)
+}
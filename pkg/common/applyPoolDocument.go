@@ -0,0 +1,338 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// ApplyPoolDocument creates a user pool from a pool document, or brings an existing pool in line with it.
+// Groups, resource servers, identity providers and app clients are matched by name and created or updated.
+// Nothing that is missing from the document is deleted. Settings that Cognito does not allow to change
+// after creation, such as alias attributes, are reported and skipped.
+// Parameters:
+//   - doc: The pool document to apply
+//   - userPoolId: ID of the pool to update, empty to create a new pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the API calls
+//   - report: Called with a description of every change made
+//
+// Returns:
+//   - string: ID of the created or updated user pool
+//   - error: The first error that occurred, changes made before it are kept
+func ApplyPoolDocument(doc *PoolDocument, userPoolId string, awsConfig aws.Config, ctx context.Context, report func(string)) (string, error) {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	var err error
+	if userPoolId == "" {
+		userPoolId, err = createPoolFromDocument(cogClient, doc, ctx)
+		if err != nil {
+			return "", err
+		}
+		report(fmt.Sprintf("Created user pool %s (%s)", aws.ToString(doc.Pool.PoolName), userPoolId))
+	} else {
+		if err := updatePoolFromDocument(cogClient, doc, userPoolId, awsConfig, ctx, report); err != nil {
+			return userPoolId, err
+		}
+		report(fmt.Sprintf("Updated user pool %s (%s)", aws.ToString(doc.Pool.PoolName), userPoolId))
+	}
+
+	if err := applyGroups(doc.Groups, userPoolId, awsConfig, ctx, report); err != nil {
+		return userPoolId, err
+	}
+	if err := applyResourceServers(cogClient, doc.ResourceServers, userPoolId, awsConfig, ctx, report); err != nil {
+		return userPoolId, err
+	}
+	if err := applyIdentityProviders(cogClient, doc.IdentityProviders, userPoolId, awsConfig, ctx, report); err != nil {
+		return userPoolId, err
+	}
+	if err := applyClients(cogClient, doc.Clients, userPoolId, awsConfig, ctx, report); err != nil {
+		return userPoolId, err
+	}
+
+	return userPoolId, nil
+}
+
+// createPoolFromDocument creates a new user pool with the settings and schema of the document
+func createPoolFromDocument(cogClient *cognitoidentityprovider.Client, doc *PoolDocument, ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	input := doc.Pool
+	output, err := cogClient.CreateUserPool(ctx, &input)
+	if err != nil {
+		return "", fmt.Errorf("failed to create user pool %s: %w", aws.ToString(doc.Pool.PoolName), err)
+	}
+	return aws.ToString(output.UserPool.Id), nil
+}
+
+// updatePoolFromDocument replaces the mutable settings of a user pool and adds missing custom attributes
+func updatePoolFromDocument(cogClient *cognitoidentityprovider.Client, doc *PoolDocument, userPoolId string, awsConfig aws.Config, ctx context.Context, report func(string)) error {
+	current, err := DescribeUserPool(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to describe user pool %s: %w", userPoolId, err)
+	}
+
+	pool := doc.Pool
+	immutable := []struct {
+		name             string
+		current, desired any
+	}{
+		{"AliasAttributes", current.AliasAttributes, pool.AliasAttributes},
+		{"UsernameAttributes", current.UsernameAttributes, pool.UsernameAttributes},
+		{"UsernameConfiguration", current.UsernameConfiguration, pool.UsernameConfiguration},
+	}
+	for _, setting := range immutable {
+		if !sameJSON(setting.current, setting.desired) {
+			report(fmt.Sprintf("Skipped %s, it cannot be changed after the pool is created", setting.name))
+		}
+	}
+
+	updateCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err = cogClient.UpdateUserPool(updateCtx, &cognitoidentityprovider.UpdateUserPoolInput{
+		UserPoolId:                  aws.String(userPoolId),
+		PoolName:                    pool.PoolName,
+		AccountRecoverySetting:      pool.AccountRecoverySetting,
+		AdminCreateUserConfig:       pool.AdminCreateUserConfig,
+		AutoVerifiedAttributes:      pool.AutoVerifiedAttributes,
+		DeletionProtection:          pool.DeletionProtection,
+		DeviceConfiguration:         pool.DeviceConfiguration,
+		EmailConfiguration:          pool.EmailConfiguration,
+		EmailVerificationMessage:    pool.EmailVerificationMessage,
+		EmailVerificationSubject:    pool.EmailVerificationSubject,
+		LambdaConfig:                pool.LambdaConfig,
+		MfaConfiguration:            pool.MfaConfiguration,
+		Policies:                    pool.Policies,
+		SmsAuthenticationMessage:    pool.SmsAuthenticationMessage,
+		SmsConfiguration:            pool.SmsConfiguration,
+		SmsVerificationMessage:      pool.SmsVerificationMessage,
+		UserAttributeUpdateSettings: pool.UserAttributeUpdateSettings,
+		UserPoolAddOns:              pool.UserPoolAddOns,
+		UserPoolTags:                pool.UserPoolTags,
+		UserPoolTier:                pool.UserPoolTier,
+		VerificationMessageTemplate: pool.VerificationMessageTemplate,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update user pool %s: %w", userPoolId, err)
+	}
+
+	// Existing attributes cannot be changed, only custom attributes can be added
+	existing := make(map[string]bool)
+	for _, attr := range current.SchemaAttributes {
+		name, _ := customAttributeName(aws.ToString(attr.Name))
+		existing[name] = true
+	}
+	var missing []types.SchemaAttributeType
+	for _, attr := range pool.Schema {
+		if !existing[aws.ToString(attr.Name)] {
+			missing = append(missing, attr)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	addCtx, cancelAdd := context.WithTimeout(ctx, 10*time.Second)
+	defer cancelAdd()
+
+	_, err = cogClient.AddCustomAttributes(addCtx, &cognitoidentityprovider.AddCustomAttributesInput{
+		UserPoolId:       aws.String(userPoolId),
+		CustomAttributes: missing,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add custom attributes to user pool %s: %w", userPoolId, err)
+	}
+	for _, attr := range missing {
+		report(fmt.Sprintf("Added custom attribute %s", aws.ToString(attr.Name)))
+	}
+	return nil
+}
+
+// applyGroups creates the groups of the document that do not exist yet and updates the others
+func applyGroups(groups []GroupDefinition, userPoolId string, awsConfig aws.Config, ctx context.Context, report func(string)) error {
+	names, err := GetGroupsFromPool(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list groups of user pool %s: %w", userPoolId, err)
+	}
+	existing := make(map[string]bool)
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	for _, group := range groups {
+		if existing[group.GroupName] {
+			if err := UpdateGroup(userPoolId, group.GroupName, group.GroupSettings, awsConfig); err != nil {
+				return err
+			}
+			report(fmt.Sprintf("Updated group %s", group.GroupName))
+			continue
+		}
+		if err := CreateGroup(userPoolId, group.GroupName, group.GroupSettings, awsConfig); err != nil {
+			return err
+		}
+		report(fmt.Sprintf("Created group %s", group.GroupName))
+	}
+	return nil
+}
+
+// applyResourceServers creates the resource servers of the document that do not exist yet and updates the others
+func applyResourceServers(cogClient *cognitoidentityprovider.Client, servers []cognitoidentityprovider.CreateResourceServerInput, userPoolId string, awsConfig aws.Config, ctx context.Context, report func(string)) error {
+	current, err := GetResourceServers(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list resource servers of user pool %s: %w", userPoolId, err)
+	}
+	existing := make(map[string]bool)
+	for _, server := range current {
+		existing[aws.ToString(server.Identifier)] = true
+	}
+
+	for _, server := range servers {
+		identifier := aws.ToString(server.Identifier)
+		callCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		if existing[identifier] {
+			_, err = cogClient.UpdateResourceServer(callCtx, &cognitoidentityprovider.UpdateResourceServerInput{
+				UserPoolId: aws.String(userPoolId),
+				Identifier: server.Identifier,
+				Name:       server.Name,
+				Scopes:     server.Scopes,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("failed to update resource server %s: %w", identifier, err)
+			}
+			report(fmt.Sprintf("Updated resource server %s", identifier))
+			continue
+		}
+		server.UserPoolId = aws.String(userPoolId)
+		_, err = cogClient.CreateResourceServer(callCtx, &server)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to create resource server %s: %w", identifier, err)
+		}
+		report(fmt.Sprintf("Created resource server %s", identifier))
+	}
+	return nil
+}
+
+// applyIdentityProviders creates the identity providers of the document that do not exist yet and updates the others
+func applyIdentityProviders(cogClient *cognitoidentityprovider.Client, providers []cognitoidentityprovider.CreateIdentityProviderInput, userPoolId string, awsConfig aws.Config, ctx context.Context, report func(string)) error {
+	names, err := GetIdentityProviders(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list identity providers of user pool %s: %w", userPoolId, err)
+	}
+	existing := make(map[string]bool)
+	for _, name := range names {
+		existing[name] = true
+	}
+
+	for _, provider := range providers {
+		name := aws.ToString(provider.ProviderName)
+		callCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		if existing[name] {
+			_, err = cogClient.UpdateIdentityProvider(callCtx, &cognitoidentityprovider.UpdateIdentityProviderInput{
+				UserPoolId:       aws.String(userPoolId),
+				ProviderName:     provider.ProviderName,
+				ProviderDetails:  provider.ProviderDetails,
+				AttributeMapping: provider.AttributeMapping,
+				IdpIdentifiers:   provider.IdpIdentifiers,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("failed to update identity provider %s: %w", name, err)
+			}
+			report(fmt.Sprintf("Updated identity provider %s", name))
+			continue
+		}
+		provider.UserPoolId = aws.String(userPoolId)
+		_, err = cogClient.CreateIdentityProvider(callCtx, &provider)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to create identity provider %s: %w", name, err)
+		}
+		report(fmt.Sprintf("Created identity provider %s", name))
+	}
+	return nil
+}
+
+// applyClients creates the app clients of the document that do not exist yet and updates the others
+func applyClients(cogClient *cognitoidentityprovider.Client, clients []cognitoidentityprovider.CreateUserPoolClientInput, userPoolId string, awsConfig aws.Config, ctx context.Context, report func(string)) error {
+	current, err := GetPoolClients(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list app clients of user pool %s: %w", userPoolId, err)
+	}
+	existing := make(map[string]*string)
+	for _, client := range current {
+		if _, ok := existing[aws.ToString(client.ClientName)]; !ok {
+			existing[aws.ToString(client.ClientName)] = client.ClientId
+		}
+	}
+
+	for _, client := range clients {
+		name := aws.ToString(client.ClientName)
+		callCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		if clientId, ok := existing[name]; ok {
+			_, err = cogClient.UpdateUserPoolClient(callCtx, &cognitoidentityprovider.UpdateUserPoolClientInput{
+				UserPoolId:                               aws.String(userPoolId),
+				ClientId:                                 clientId,
+				ClientName:                               client.ClientName,
+				AccessTokenValidity:                      client.AccessTokenValidity,
+				AllowedOAuthFlows:                        client.AllowedOAuthFlows,
+				AllowedOAuthFlowsUserPoolClient:          client.AllowedOAuthFlowsUserPoolClient,
+				AllowedOAuthScopes:                       client.AllowedOAuthScopes,
+				AnalyticsConfiguration:                   client.AnalyticsConfiguration,
+				AuthSessionValidity:                      client.AuthSessionValidity,
+				CallbackURLs:                             client.CallbackURLs,
+				DefaultRedirectURI:                       client.DefaultRedirectURI,
+				EnablePropagateAdditionalUserContextData: client.EnablePropagateAdditionalUserContextData,
+				EnableTokenRevocation:                    client.EnableTokenRevocation,
+				ExplicitAuthFlows:                        client.ExplicitAuthFlows,
+				IdTokenValidity:                          client.IdTokenValidity,
+				LogoutURLs:                               client.LogoutURLs,
+				PreventUserExistenceErrors:               client.PreventUserExistenceErrors,
+				ReadAttributes:                           client.ReadAttributes,
+				RefreshTokenValidity:                     client.RefreshTokenValidity,
+				SupportedIdentityProviders:               client.SupportedIdentityProviders,
+				TokenValidityUnits:                       client.TokenValidityUnits,
+				WriteAttributes:                          client.WriteAttributes,
+			})
+			cancel()
+			if err != nil {
+				return fmt.Errorf("failed to update app client %s: %w", name, err)
+			}
+			report(fmt.Sprintf("Updated app client %s", name))
+			continue
+		}
+		client.UserPoolId = aws.String(userPoolId)
+		_, err = cogClient.CreateUserPoolClient(callCtx, &client)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to create app client %s: %w", name, err)
+		}
+		report(fmt.Sprintf("Created app client %s", name))
+	}
+	return nil
+}
+
+// sameJSON reports whether two values encode to the same JSON, treating nil and empty values alike
+func sameJSON(a any, b any) bool {
+	encode := func(value any) string {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		switch string(encoded) {
+		case "null", "[]", "{}":
+			return ""
+		}
+		return string(encoded)
+	}
+	return encode(a) == encode(b)
+}
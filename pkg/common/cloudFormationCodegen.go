@@ -0,0 +1,208 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gopkg.in/yaml.v3"
+)
+
+// cfnImport is one entry of the resources-to-import list of a CloudFormation IMPORT change set
+type cfnImport struct {
+	ResourceType       string
+	LogicalResourceId  string
+	ResourceIdentifier map[string]string
+}
+
+// WriteCloudFormation writes the document as a CloudFormation template. Every resource is retained on
+// deletion, as CloudFormation requires for imports, and the resources-to-import list for the live
+// resources is written as a comment. Identity provider secrets become NoEcho parameters.
+// Parameters:
+//   - w: Writer that receives the template
+//   - ids: IDs of the live resources to import
+//
+// Returns:
+//   - error: Any error encoding the template
+func (d *PoolDocument) WriteCloudFormation(w io.Writer, ids PoolResourceIds) error {
+	resources := make(map[string]any)
+	parameters := make(map[string]any)
+	var imports []cfnImport
+	names := cfnNamer{}
+	poolRef := map[string]any{"Ref": "UserPool"}
+
+	add := func(logicalId string, resourceType string, value any, renames map[string]string, identifier map[string]string) (map[string]any, error) {
+		properties, err := cfnProperties(value, renames)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", logicalId, err)
+		}
+		if resourceType != "AWS::Cognito::UserPool" {
+			properties["UserPoolId"] = poolRef
+		}
+		resource := map[string]any{
+			"Type":                resourceType,
+			"DeletionPolicy":      "Retain",
+			"UpdateReplacePolicy": "Retain",
+			"Properties":          properties,
+		}
+		resources[logicalId] = resource
+		imports = append(imports, cfnImport{ResourceType: resourceType, LogicalResourceId: logicalId, ResourceIdentifier: identifier})
+		return resource, nil
+	}
+
+	names.name("UserPool", "")
+	_, err := add("UserPool", "AWS::Cognito::UserPool", d.Pool, map[string]string{"PoolName": "UserPoolName"},
+		map[string]string{"UserPoolId": ids.UserPoolId})
+	if err != nil {
+		return err
+	}
+
+	for _, group := range d.Groups {
+		_, err := add(names.name("Group", group.GroupName), "AWS::Cognito::UserPoolGroup", group, nil,
+			map[string]string{"UserPoolId": ids.UserPoolId, "GroupName": group.GroupName})
+		if err != nil {
+			return err
+		}
+	}
+
+	var dependencies []string
+	for _, server := range d.ResourceServers {
+		logicalId := names.name("ResourceServer", aws.ToString(server.Identifier))
+		_, err := add(logicalId, "AWS::Cognito::UserPoolResourceServer", server, nil,
+			map[string]string{"UserPoolId": ids.UserPoolId, "Identifier": aws.ToString(server.Identifier)})
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, logicalId)
+	}
+
+	for _, provider := range d.IdentityProviders {
+		logicalId := names.name("IdentityProvider", aws.ToString(provider.ProviderName))
+		resource, err := add(logicalId, "AWS::Cognito::UserPoolIdentityProvider", provider, nil,
+			map[string]string{"UserPoolId": ids.UserPoolId, "ProviderName": aws.ToString(provider.ProviderName)})
+		if err != nil {
+			return err
+		}
+		dependencies = append(dependencies, logicalId)
+
+		// Replace secret placeholders with references to NoEcho parameters
+		details, _ := resource["Properties"].(map[string]any)["ProviderDetails"].(map[string]any)
+		for key, value := range details {
+			text, _ := value.(string)
+			if match := secretReference.FindStringSubmatch(text); match != nil {
+				parameter := cfnParameterName(match[1])
+				parameters[parameter] = map[string]any{"Type": "String", "NoEcho": true}
+				details[key] = map[string]any{"Ref": parameter}
+			}
+		}
+	}
+
+	for _, client := range d.Clients {
+		clientName := aws.ToString(client.ClientName)
+		identifier := map[string]string{"UserPoolId": ids.UserPoolId, "ClientId": ids.ClientIds[clientName]}
+		resource, err := add(names.name("Client", clientName), "AWS::Cognito::UserPoolClient", client, nil, identifier)
+		if err != nil {
+			return err
+		}
+		if len(dependencies) > 0 {
+			resource["DependsOn"] = dependencies
+		}
+	}
+
+	template := map[string]any{
+		"AWSTemplateFormatVersion": "2010-09-09",
+		"Description":              fmt.Sprintf("Cognito user pool %s", aws.ToString(d.Pool.PoolName)),
+		"Resources":                resources,
+	}
+	if len(parameters) > 0 {
+		template["Parameters"] = parameters
+	}
+
+	fmt.Fprintf(w, "# Generated by cognitousermanagement from user pool %s\n", ids.UserPoolId)
+	fmt.Fprintln(w, "# Import the live resources with a change set of type IMPORT using these resources to import:")
+	for i, entry := range imports {
+		encoded, err := json.Marshal(entry)
+		if err != nil {
+			return fmt.Errorf("failed to encode resources to import: %w", err)
+		}
+		separator, open := ",", " "
+		if i == 0 {
+			open = "["
+		}
+		if i == len(imports)-1 {
+			separator = "]"
+		}
+		fmt.Fprintf(w, "# %s%s%s\n", open, encoded, separator)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(template); err != nil {
+		return fmt.Errorf("failed to write template: %w", err)
+	}
+	return encoder.Close()
+}
+
+// cfnProperties converts an SDK input to CloudFormation properties, which share the field names of the
+// Cognito API, leaving out unset fields and renaming the fields that differ
+func cfnProperties(value any, renames map[string]string) (map[string]any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var tree any
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	properties, _ := yamlTree(pruneTree(tree)).(map[string]any)
+	if properties == nil {
+		properties = make(map[string]any)
+	}
+	for from, to := range renames {
+		if value, ok := properties[from]; ok {
+			properties[to] = value
+			delete(properties, from)
+		}
+	}
+	return properties, nil
+}
+
+// cfnParameterName turns a placeholder variable such as IDP_GOOGLE_CLIENT_SECRET into IdpGoogleClientSecret
+func cfnParameterName(variable string) string {
+	var name strings.Builder
+	for _, part := range strings.Split(strings.ToLower(variable), "_") {
+		if part != "" {
+			name.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return name.String()
+}
+
+// invalidLogicalIdChars matches characters that cannot appear in a CloudFormation logical ID
+var invalidLogicalIdChars = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// cfnNamer hands out unique CloudFormation logical IDs
+type cfnNamer map[string]bool
+
+// name derives a logical ID such as GroupTenantAdmins from a prefix and a Cognito name
+func (n cfnNamer) name(prefix string, value string) string {
+	name := prefix
+	for _, part := range invalidLogicalIdChars.Split(value, -1) {
+		if part != "" {
+			name += strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	unique := name
+	for i := 2; n[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	n[unique] = true
+	return unique
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+// ConfirmSignUp confirms the registration of an UNCONFIRMED user without a confirmation code
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the user to confirm
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func ConfirmSignUp(userPoolId string, userName string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Create the input for the AdminConfirmSignUp API call
+	input := &cognitoidentityprovider.AdminConfirmSignUpInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminConfirmSignUp API
+	_, err := cogClient.AdminConfirmSignUp(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to confirm user %s: %w", userName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+// GroupSettings holds the configurable properties of a Cognito group
+type GroupSettings struct {
+	Description string // Free text description of the group
+	Precedence  *int32 // Lower values take priority when a user is in several groups, nil for none
+	RoleArn     string // IAM role assumed by members through an identity pool
+}
+
+// CreateGroup creates a new group in a Cognito user pool
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - groupName: Name of the new group
+//   - settings: Description, precedence and IAM role of the group
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func CreateGroup(userPoolId string, groupName string, settings GroupSettings, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Create the input for the CreateGroup API call
+	input := &cognitoidentityprovider.CreateGroupInput{
+		UserPoolId: aws.String(userPoolId),
+		GroupName:  aws.String(groupName),
+		Precedence: settings.Precedence,
+	}
+	if settings.Description != "" {
+		input.Description = aws.String(settings.Description)
+	}
+	if settings.RoleArn != "" {
+		input.RoleArn = aws.String(settings.RoleArn)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the CreateGroup API
+	_, err := cogClient.CreateGroup(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create group %s: %w", groupName, err)
+	}
+
+	return nil
+}
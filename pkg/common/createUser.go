@@ -11,17 +11,26 @@ import (
 	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
 )
 
-func CreateUser(userPoolId string, userName string, tempPassword string, permpass bool, AwsConfig aws.Config) error {
+// InviteOptions controls whether and how Cognito delivers the invitation message to a user
+type InviteOptions struct {
+	MessageAction      types.MessageActionType    // SUPPRESS to skip the message, RESEND to resend it, empty to send it
+	DeliveryMediums    []types.DeliveryMediumType // Channels the invitation is sent through
+	ForceAliasCreation bool                       // Move an email/phone alias already used by another user to this user
+}
+
+func CreateUser(userPoolId string, userName string, tempPassword string, permpass bool, invite InviteOptions, AwsConfig aws.Config) error {
 
 	// Initialize Cognito client with AWS configuration
 	cogClient := cognitoidentityprovider.NewFromConfig(AwsConfig)
 
 	// Prepare user creation input parameters
 	userInput := cognitoidentityprovider.AdminCreateUserInput{
-		UserPoolId:        &userPoolId,
-		Username:          &userName,
-		MessageAction:     types.MessageActionTypeSuppress,
-		TemporaryPassword: &tempPassword,
+		UserPoolId:             &userPoolId,
+		Username:               &userName,
+		MessageAction:          invite.MessageAction,
+		DesiredDeliveryMediums: invite.DeliveryMediums,
+		ForceAliasCreation:     invite.ForceAliasCreation,
+		TemporaryPassword:      &tempPassword,
 	}
 
 	// Set timeout context for AWS API call
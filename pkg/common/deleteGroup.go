@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+func DeleteGroup(userPoolId string, groupName string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Create the input for the DeleteGroup API call
+	input := &cognitoidentityprovider.DeleteGroupInput{
+		UserPoolId: aws.String(userPoolId),
+		GroupName:  aws.String(groupName),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the DeleteGroup API
+	_, err := cogClient.DeleteGroup(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to delete group %s: %w", groupName, err)
+	}
+
+	return nil
+}
@@ -6,24 +6,26 @@ import (
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 )
 
-// DescribeUserSignInAttr retrieves the sign-in attributes configured for a Cognito user pool
+// DescribeUserPool retrieves the full configuration of a Cognito user pool
 // Parameters:
 //   - userPoolId: ID of the Cognito user pool to describe
 //   - AwsConfig: AWS configuration for the Cognito client
 //   - ctx: Context for the API call
+//
 // Returns:
-//   - []string: List of configured sign-in attributes
+//   - *types.UserPoolType: The configuration of the user pool
 //   - error: Any error that occurred during the operation
-func DescribeUserSignInAttr(userPoolId *string, AwsConfig aws.Config, ctx context.Context) ([]string, error) {
+func DescribeUserPool(userPoolId string, AwsConfig aws.Config, ctx context.Context) (*types.UserPoolType, error) {
 
 	// Initialize Cognito client with AWS configuration
 	cogClient := cognitoidentityprovider.NewFromConfig(AwsConfig)
 
 	// Prepare input parameters for DescribeUserPool API call
 	DescribeUserPoolInput := cognitoidentityprovider.DescribeUserPoolInput{
-		UserPoolId: userPoolId,
+		UserPoolId: &userPoolId,
 	}
 
 	// Set timeout of 10 seconds for the API call
@@ -38,11 +40,32 @@ func DescribeUserSignInAttr(userPoolId *string, AwsConfig aws.Config, ctx contex
 		return nil, err
 	}
 
+	return DescribeUserPoolOutput.UserPool, nil
+}
+
+// DescribeUserSignInAttr retrieves the sign-in attributes configured for a Cognito user pool
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool to describe
+//   - AwsConfig: AWS configuration for the Cognito client
+//   - ctx: Context for the API call
+// Returns:
+//   - []string: List of configured sign-in attributes
+//   - error: Any error that occurred during the operation
+func DescribeUserSignInAttr(userPoolId *string, AwsConfig aws.Config, ctx context.Context) ([]string, error) {
+
+	// Call Cognito API to get user pool details
+	userPool, err := DescribeUserPool(*userPoolId, AwsConfig, ctx)
+
+	// Return error if API call fails
+	if err != nil {
+		return nil, err
+	}
+
 	// Initialize slice to store attributes
 	var attrs []string
 
 	// Get sign-in identifiers (username attributes) from the user pool
-	signInOptions := DescribeUserPoolOutput.UserPool.UsernameAttributes
+	signInOptions := userPool.UsernameAttributes
 	// Convert each attribute to string and append to result slice
 	for _, attr := range signInOptions {
 		attrs = append(attrs, string(attr))
@@ -51,3 +74,23 @@ func DescribeUserSignInAttr(userPoolId *string, AwsConfig aws.Config, ctx contex
 	// Return the list of sign-in attributes
 	return attrs, nil
 }
+
+// GetPasswordPolicy retrieves the password policy of a Cognito user pool
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - AwsConfig: AWS configuration for the Cognito client
+//   - ctx: Context for the API call
+//
+// Returns:
+//   - *types.PasswordPolicyType: The password policy, nil when the pool has none
+//   - error: Any error that occurred during the operation
+func GetPasswordPolicy(userPoolId string, AwsConfig aws.Config, ctx context.Context) (*types.PasswordPolicyType, error) {
+	userPool, err := DescribeUserPool(userPoolId, AwsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+	if userPool.Policies == nil {
+		return nil, nil
+	}
+	return userPool.Policies.PasswordPolicy, nil
+}
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// DisableProviderForUser unlinks an external identity from the Cognito user it is linked to
+// and prevents it from signing in until it is linked again
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - providerName: The name of the identity provider in the user pool
+//   - providerUserId: The subject of the user at the identity provider
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func DisableProviderForUser(userPoolId string, providerName string, providerUserId string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	input := &cognitoidentityprovider.AdminDisableProviderForUserInput{
+		UserPoolId: aws.String(userPoolId),
+		User: &types.ProviderUserIdentifierType{
+			ProviderName:           aws.String(providerName),
+			ProviderAttributeName:  aws.String("Cognito_Subject"),
+			ProviderAttributeValue: aws.String(providerUserId),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminDisableProviderForUser API
+	_, err := cogClient.AdminDisableProviderForUser(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to unlink %s identity %s: %w", providerName, providerUserId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+func ForgetDevice(userPoolId string, userName string, deviceKey string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	input := &cognitoidentityprovider.AdminForgetDeviceInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+		DeviceKey:  aws.String(deviceKey),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminForgetDevice API
+	_, err := cogClient.AdminForgetDevice(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to forget device %s: %w", deviceKey, err)
+	}
+
+	return nil
+}
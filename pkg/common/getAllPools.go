@@ -2,50 +2,43 @@ package common
 
 import (
 	"context"
-	"time"
+	"iter"
+
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 )
 
-func GetAllPools(cfg aws.Config) ([]string, error) {
-	// Create slice to store User Pool IDs
-	var userPools []string
-
+// GetAllPools retrieves the IDs of all user pools in the account and region
+func GetAllPools(cfg aws.Config, ctx context.Context) ([]string, error) {
+	return collect(ListUserPools(cfg, ctx, nil), func(pool types.UserPoolDescriptionType) string {
+		return *pool.Id
+	})
+}
+
+// ListUserPools streams the user pools of the account and region page by page
+// Parameters:
+//   - cfg: AWS configuration object
+//   - ctx: Context that cancels the listing
+//   - progress: Optional callback invoked after every page
+//
+// Returns:
+//   - iter.Seq2[types.UserPoolDescriptionType, error]: The pools, or a single error that ends the stream
+func ListUserPools(cfg aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.UserPoolDescriptionType, error] {
 	// Initialize Cognito client with provided config
 	cogClient := cognitoidentityprovider.NewFromConfig(cfg)
 
-	// Set maximum number of User Pools to retrieve
+	// Set maximum number of User Pools to retrieve per page
 	var maxResults int32 = 20
 
-	// Configure input parameters for ListUserPools API call
-	listUserPoolInputs := &cognitoidentityprovider.ListUserPoolsInput{
-		MaxResults: &maxResults,
-	}
-
-	gotAllPools := false
-	for !gotAllPools {
-		// Create background context
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-
-		// Call ListUserPools API and handle any errors
-		listUserPoolOutput, err := cogClient.ListUserPools(ctx, listUserPoolInputs)
-
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.UserPoolDescriptionType, *string, error) {
+		output, err := cogClient.ListUserPools(ctx, &cognitoidentityprovider.ListUserPoolsInput{
+			MaxResults: &maxResults,
+			NextToken:  token,
+		})
 		if err != nil {
-			return nil, err
-		}
-
-		// Extract User Pool IDs from response
-		for i := range listUserPoolOutput.UserPools {
-			userPools = append(userPools, *listUserPoolOutput.UserPools[i].Id)
-		}
-
-		if listUserPoolOutput.NextToken == nil {
-			gotAllPools = true
-		} else {
-			// Set the next token for the next iteration
-			listUserPoolInputs.NextToken = listUserPoolOutput.NextToken
+			return nil, nil, err
 		}
-	}
-	return userPools, nil
-}
\ No newline at end of file
+		return output.UserPools, output.NextToken, nil
+	})
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetDevice retrieves a single remembered device of a user
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the device owner
+//   - deviceKey: The key of the device
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - *types.DeviceType: The device and its attributes
+//   - error: Any error that occurred during the operation
+func GetDevice(userPoolId string, userName string, deviceKey string, awsConfig aws.Config) (*types.DeviceType, error) {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	input := &cognitoidentityprovider.AdminGetDeviceInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+		DeviceKey:  aws.String(deviceKey),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminGetDevice API
+	output, err := cogClient.AdminGetDevice(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Device, nil
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetGroup retrieves the details of a single group in a Cognito user pool
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - groupName: Name of the group to retrieve
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - *types.GroupType: Description, precedence, role and dates of the group
+//   - error: Any error that occurred during the operation
+func GetGroup(userPoolId string, groupName string, awsConfig aws.Config) (*types.GroupType, error) {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	input := &cognitoidentityprovider.GetGroupInput{
+		UserPoolId: aws.String(userPoolId),
+		GroupName:  aws.String(groupName),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the GetGroup API
+	output, err := cogClient.GetGroup(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return output.Group, nil
+}
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"iter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetGroupsForUser retrieves the names of the groups a user currently belongs to
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - userName: Username of the user
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//
+// Returns:
+//   - []string: Names of the groups the user is a member of
+//   - error: Error if the operation fails
+func GetGroupsForUser(userPoolId string, userName string, awsConfig aws.Config, ctx context.Context) ([]string, error) {
+	return collect(ListGroupsForUser(userPoolId, userName, awsConfig, ctx, nil), func(group types.GroupType) string {
+		return *group.GroupName
+	})
+}
+
+// ListGroupsForUser streams the groups a user belongs to page by page
+func ListGroupsForUser(userPoolId string, userName string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.GroupType, error] {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.GroupType, *string, error) {
+		output, err := cogClient.AdminListGroupsForUser(ctx, &cognitoidentityprovider.AdminListGroupsForUserInput{
+			UserPoolId: &userPoolId,
+			Username:   &userName,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Groups, output.NextToken, nil
+	})
+}
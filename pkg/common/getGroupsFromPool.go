@@ -2,35 +2,41 @@ package common
 
 import (
 	"context"
-	"time"
+	"iter"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
 )
 
-func GetGroupsFromPool(userPoolId string, awsConfig aws.Config) ([]string, error) {
+// GetGroupsFromPool retrieves the names of all groups in a Cognito user pool
+func GetGroupsFromPool(userPoolId string, awsConfig aws.Config, ctx context.Context) ([]string, error) {
+	return collect(ListGroups(userPoolId, awsConfig, ctx, nil), func(group types.GroupType) string {
+		return *group.GroupName
+	})
+}
+
+// ListGroups streams the groups of a Cognito user pool page by page
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//   - progress: Optional callback invoked after every page
+//
+// Returns:
+//   - iter.Seq2[types.GroupType, error]: The groups, or a single error that ends the stream
+func ListGroups(userPoolId string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.GroupType, error] {
 	// Initialize Cognito client with AWS configuration
 	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
 
-	// Create the input for the ListGroups API call
-	input := &cognitoidentityprovider.ListGroupsInput{
-		UserPoolId: &userPoolId,
-	}
-
-	// Call the ListGroups API
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
-	output, err := cogClient.ListGroups(ctx, input)
-	if err != nil {
-		return nil, err
-	}
-
-	// Extract group names from the output
-	var groups []string
-	for _, group := range output.Groups {
-		groups = append(groups, *group.GroupName)
-	}
-
-	return groups, nil
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.GroupType, *string, error) {
+		output, err := cogClient.ListGroups(ctx, &cognitoidentityprovider.ListGroupsInput{
+			UserPoolId: &userPoolId,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Groups, output.NextToken, nil
+	})
 }
@@ -0,0 +1,74 @@
+package common
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetIdentityProviders retrieves the names of all identity providers of a user pool
+func GetIdentityProviders(userPoolId string, awsConfig aws.Config, ctx context.Context) ([]string, error) {
+	return collect(ListIdentityProviders(userPoolId, awsConfig, ctx, nil), func(provider types.ProviderDescription) string {
+		return *provider.ProviderName
+	})
+}
+
+// ListIdentityProviders streams the identity providers of a user pool page by page
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//   - progress: Optional callback invoked after every page
+//
+// Returns:
+//   - iter.Seq2[types.ProviderDescription, error]: The providers, or a single error that ends the stream
+func ListIdentityProviders(userPoolId string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.ProviderDescription, error] {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	var maxResults int32 = 60
+
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.ProviderDescription, *string, error) {
+		output, err := cogClient.ListIdentityProviders(ctx, &cognitoidentityprovider.ListIdentityProvidersInput{
+			UserPoolId: &userPoolId,
+			MaxResults: &maxResults,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Providers, output.NextToken, nil
+	})
+}
+
+// DescribeIdentityProvider retrieves the configuration and attribute mapping of an identity provider
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - providerName: Name of the identity provider
+//   - awsConfig: AWS configuration object
+//   - ctx: Context for the API call
+//
+// Returns:
+//   - *types.IdentityProviderType: The configuration of the identity provider
+//   - error: Any error that occurred during the operation
+func DescribeIdentityProvider(userPoolId string, providerName string, awsConfig aws.Config, ctx context.Context) (*types.IdentityProviderType, error) {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	output, err := cogClient.DescribeIdentityProvider(ctx, &cognitoidentityprovider.DescribeIdentityProviderInput{
+		UserPoolId:   aws.String(userPoolId),
+		ProviderName: aws.String(providerName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.IdentityProvider, nil
+}
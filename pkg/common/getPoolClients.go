@@ -0,0 +1,74 @@
+package common
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetPoolClients retrieves the name and ID of every app client of a user pool
+func GetPoolClients(userPoolId string, awsConfig aws.Config, ctx context.Context) ([]types.UserPoolClientDescription, error) {
+	return collect(ListPoolClients(userPoolId, awsConfig, ctx, nil), func(client types.UserPoolClientDescription) types.UserPoolClientDescription {
+		return client
+	})
+}
+
+// ListPoolClients streams the app clients of a user pool page by page
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//   - progress: Optional callback invoked after every page
+//
+// Returns:
+//   - iter.Seq2[types.UserPoolClientDescription, error]: The clients, or a single error that ends the stream
+func ListPoolClients(userPoolId string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.UserPoolClientDescription, error] {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	var maxResults int32 = 60
+
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.UserPoolClientDescription, *string, error) {
+		output, err := cogClient.ListUserPoolClients(ctx, &cognitoidentityprovider.ListUserPoolClientsInput{
+			UserPoolId: &userPoolId,
+			MaxResults: &maxResults,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.UserPoolClients, output.NextToken, nil
+	})
+}
+
+// DescribePoolClient retrieves the full configuration of an app client
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - clientId: ID of the app client
+//   - awsConfig: AWS configuration object
+//   - ctx: Context for the API call
+//
+// Returns:
+//   - *types.UserPoolClientType: The configuration of the app client, including its secret
+//   - error: Any error that occurred during the operation
+func DescribePoolClient(userPoolId string, clientId string, awsConfig aws.Config, ctx context.Context) (*types.UserPoolClientType, error) {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	output, err := cogClient.DescribeUserPoolClient(ctx, &cognitoidentityprovider.DescribeUserPoolClientInput{
+		UserPoolId: aws.String(userPoolId),
+		ClientId:   aws.String(clientId),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output.UserPoolClient, nil
+}
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"context"
+	"iter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetResourceServers retrieves every resource server of a user pool with its scopes
+func GetResourceServers(userPoolId string, awsConfig aws.Config, ctx context.Context) ([]types.ResourceServerType, error) {
+	return collect(ListResourceServers(userPoolId, awsConfig, ctx, nil), func(server types.ResourceServerType) types.ResourceServerType {
+		return server
+	})
+}
+
+// ListResourceServers streams the resource servers of a user pool page by page
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//   - progress: Optional callback invoked after every page
+//
+// Returns:
+//   - iter.Seq2[types.ResourceServerType, error]: The resource servers, or a single error that ends the stream
+func ListResourceServers(userPoolId string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.ResourceServerType, error] {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	var maxResults int32 = 50
+
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.ResourceServerType, *string, error) {
+		output, err := cogClient.ListResourceServers(ctx, &cognitoidentityprovider.ListResourceServersInput{
+			UserPoolId: &userPoolId,
+			MaxResults: &maxResults,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.ResourceServers, output.NextToken, nil
+	})
+}
@@ -3,62 +3,86 @@ package common
 import (
 	"context"
 	"fmt"
-	"time"
+	"iter"
+	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
 )
 
 // GetUsersFromPool retrieves all users from a Cognito user pool
 // Parameters:
-//   userPoolId: ID of the Cognito user pool to query
-//   awsConfig: AWS configuration object
+//   - userPoolId: ID of the Cognito user pool to query
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//
 // Returns:
-//   []string: Slice containing usernames of all users in the pool
-//   error: Error if the operation fails
-func GetUsersFromPool(userPoolId string, awsConfig aws.Config) ([]string, error) {
+//   - []string: Slice containing usernames of all users in the pool
+//   - error: Error if the operation fails
+func GetUsersFromPool(userPoolId string, awsConfig aws.Config, ctx context.Context) ([]string, error) {
+	return collectUsernames(userPoolId, "", awsConfig, ctx)
+}
 
-	// Flag to track if all users have been retrieved
-	var allUsersRetrieved bool
+// GetUsersByStatus retrieves the users of a Cognito user pool that are in the given status
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool to query
+//   - status: User status to filter on, e.g. UNCONFIRMED or FORCE_CHANGE_PASSWORD
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//
+// Returns:
+//   - []string: Usernames of the matching users
+//   - error: Error if the operation fails
+func GetUsersByStatus(userPoolId string, status string, awsConfig aws.Config, ctx context.Context) ([]string, error) {
+	return collectUsernames(userPoolId, fmt.Sprintf("cognito:user_status = %q", status), awsConfig, ctx)
+}
 
-	// Slice to store usernames
-	var users []string
+// ListUsers streams the users of a Cognito user pool page by page
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool to query
+//   - filter: Optional ListUsers filter expression, empty for all users
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//   - progress: Optional callback invoked after every page
+//
+// Returns:
+//   - iter.Seq2[types.UserType, error]: The users, or a single error that ends the stream
+func ListUsers(userPoolId string, filter string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.UserType, error] {
 
 	// Initialize Cognito client with AWS configuration
 	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
 
-	// Create the input for the ListUsers API call
-	input := &cognitoidentityprovider.ListUsersInput{
-		UserPoolId: &userPoolId,
-	}
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.UserType, *string, error) {
+		input := &cognitoidentityprovider.ListUsersInput{
+			UserPoolId:      &userPoolId,
+			PaginationToken: token,
+		}
+		if filter != "" {
+			input.Filter = aws.String(filter)
+		}
 
-	// Create context with timeout for the API calls
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+		output, err := cogClient.ListUsers(ctx, input)
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Users, output.PaginationToken, nil
+	})
+}
 
-	// Loop until all users are retrieved using pagination
-	for !allUsersRetrieved {
-	
-	// Call Cognito API to get batch of users
-	output, err := cogClient.ListUsers(ctx, input)
+// collectUsernames drains ListUsers into a slice of usernames while showing progress
+func collectUsernames(userPoolId string, filter string, awsConfig aws.Config, ctx context.Context) ([]string, error) {
+	progress, done := helpers.ProgressPrinter("users")
+	users, err := collect(ListUsers(userPoolId, filter, awsConfig, ctx, progress), func(user types.UserType) string {
+		return *user.Username
+	})
+	done()
 	if err != nil {
 		return nil, err
 	}
 
-	// Extract usernames from the response and append to users slice
-	for _, user := range output.Users {
-		users = append(users, *user.Username)
-	}
-
-	// Check if there are more users to retrieve
-	if output.PaginationToken == nil {
-		allUsersRetrieved = true
-	} else {
-		// Set pagination token for next batch
-		input.PaginationToken = output.PaginationToken
-	}
-}
-	// Log the total number of users found
-	fmt.Printf("%v users found in the pool %v\n", len(users), userPoolId)
+	// Log the total number of users found, on stderr with the progress so reports on stdout stay clean
+	fmt.Fprintf(os.Stderr, "%v users found in the pool %v\n", len(users), userPoolId)
 	return users, nil
 }
@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"iter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetUsersInGroup retrieves the usernames of every member of a Cognito group
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - groupName: Name of the group to list
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//
+// Returns:
+//   - []string: Usernames of all members of the group
+//   - error: Error if the operation fails
+func GetUsersInGroup(userPoolId string, groupName string, awsConfig aws.Config, ctx context.Context) ([]string, error) {
+	return collect(ListUsersInGroup(userPoolId, groupName, awsConfig, ctx, nil), func(user types.UserType) string {
+		return *user.Username
+	})
+}
+
+// ListUsersInGroup streams the members of a Cognito group page by page
+func ListUsersInGroup(userPoolId string, groupName string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.UserType, error] {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.UserType, *string, error) {
+		output, err := cogClient.ListUsersInGroup(ctx, &cognitoidentityprovider.ListUsersInGroupInput{
+			UserPoolId: &userPoolId,
+			GroupName:  &groupName,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Users, output.NextToken, nil
+	})
+}
@@ -0,0 +1,41 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+// GlobalSignOut signs a user out of all devices and invalidates their refresh tokens
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the user to sign out
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func GlobalSignOut(userPoolId string, userName string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Create the input for the AdminUserGlobalSignOut API call
+	input := &cognitoidentityprovider.AdminUserGlobalSignOutInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminUserGlobalSignOut API
+	_, err := cogClient.AdminUserGlobalSignOut(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to sign out user %s: %w", userName, err)
+	}
+
+	return nil
+}
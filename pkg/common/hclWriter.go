@@ -0,0 +1,235 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// hclExpr is a raw HCL expression, such as a reference to another resource, written without quotes
+type hclExpr string
+
+// hclBlock is a block of a Terraform configuration with its attributes and nested blocks in order
+type hclBlock struct {
+	header  string
+	entries []hclEntry
+	err     error // first attribute that could not be rendered
+}
+
+// hclEntry is either an attribute or a nested block of an hclBlock
+type hclEntry struct {
+	name  string
+	value string
+	block *hclBlock
+}
+
+// newHCLBlock starts a block such as `resource "aws_cognito_user_pool" "pool"`
+func newHCLBlock(header string) *hclBlock {
+	return &hclBlock{header: header}
+}
+
+// set adds an attribute, leaving out nil pointers, empty strings, empty lists and empty maps
+// A value that cannot be rendered is recorded and reported by validate.
+func (b *hclBlock) set(name string, value any) {
+	rendered, ok, err := hclValue(value)
+	if err != nil {
+		if b.err == nil {
+			b.err = fmt.Errorf("%s: attribute %s: %w", b.header, name, err)
+		}
+		return
+	}
+	if ok {
+		b.entries = append(b.entries, hclEntry{name: name, value: rendered})
+	}
+}
+
+// validate returns the first attribute of the block or its nested blocks that could not be rendered
+func (b *hclBlock) validate() error {
+	if b.err != nil {
+		return b.err
+	}
+	for _, entry := range b.entries {
+		if entry.block == nil {
+			continue
+		}
+		if err := entry.block.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// block adds a nested block and returns it. Nested blocks without attributes are not written.
+func (b *hclBlock) block(header string) *hclBlock {
+	nested := newHCLBlock(header)
+	b.entries = append(b.entries, hclEntry{block: nested})
+	return nested
+}
+
+// empty reports whether the block and its nested blocks hold no attributes
+func (b *hclBlock) empty() bool {
+	for _, entry := range b.entries {
+		if entry.block == nil || !entry.block.empty() {
+			return false
+		}
+	}
+	return true
+}
+
+// write writes the block formatted the way terraform fmt does, aligning the equals signs of adjacent attributes
+func (b *hclBlock) write(w io.Writer, indent int) {
+	pad := strings.Repeat("  ", indent)
+	fmt.Fprintf(w, "%s%s {\n", pad, b.header)
+
+	for i := 0; i < len(b.entries); {
+		entry := b.entries[i]
+		if entry.block != nil {
+			if !entry.block.empty() {
+				entry.block.write(w, indent+1)
+			}
+			i++
+			continue
+		}
+
+		// Align the run of attributes up to the next nested block
+		end := i
+		width := 0
+		for end < len(b.entries) && b.entries[end].block == nil {
+			width = max(width, len(b.entries[end].name))
+			end++
+		}
+		for _, attr := range b.entries[i:end] {
+			value := strings.ReplaceAll(attr.value, "\n", "\n"+pad+"  ")
+			fmt.Fprintf(w, "%s  %-*s = %s\n", pad, width, attr.name, value)
+		}
+		i = end
+	}
+
+	fmt.Fprintf(w, "%s}\n", pad)
+}
+
+// hclValue renders a Go value as an HCL expression, reporting false for values that should be left out
+// and an error for types it cannot render
+func hclValue(value any) (string, bool, error) {
+	switch value := value.(type) {
+	case hclExpr:
+		return string(value), value != "", nil
+	case string:
+		return hclString(value), value != "", nil
+	case *string:
+		if value == nil {
+			return "", false, nil
+		}
+		return hclString(*value), true, nil
+	case bool:
+		return fmt.Sprint(value), true, nil
+	case *bool:
+		if value == nil {
+			return "", false, nil
+		}
+		return fmt.Sprint(*value), true, nil
+	case int32:
+		return fmt.Sprint(value), true, nil
+	case *int32:
+		if value == nil {
+			return "", false, nil
+		}
+		return fmt.Sprint(*value), true, nil
+	case []string:
+		if len(value) == 0 {
+			return "", false, nil
+		}
+		items := make([]string, len(value))
+		for i, item := range value {
+			items[i] = hclString(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]", true, nil
+	case map[string]hclExpr:
+		if len(value) == 0 {
+			return "", false, nil
+		}
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		width := 0
+		for _, key := range keys {
+			width = max(width, len(hclString(key)))
+		}
+		var rendered strings.Builder
+		rendered.WriteString("{\n")
+		for _, key := range keys {
+			fmt.Fprintf(&rendered, "  %-*s = %s\n", width, hclString(key), value[key])
+		}
+		rendered.WriteString("}")
+		return rendered.String(), true, nil
+	case map[string]string:
+		expressions := make(map[string]hclExpr, len(value))
+		for key, item := range value {
+			expressions[key] = hclExpr(hclString(item))
+		}
+		return hclValue(expressions)
+	default:
+		return "", false, fmt.Errorf("unsupported HCL value %T", value)
+	}
+}
+
+// hclString quotes a string using the escapes of HCL quoted templates. Control and non-printable characters
+// become \uNNNN escapes, invalid UTF-8 becomes U+FFFD and the interpolation sequences ${ and %{ are escaped.
+func hclString(value string) string {
+	var quoted strings.Builder
+	quoted.WriteByte('"')
+	for i, r := range value {
+		switch {
+		case r == '"':
+			quoted.WriteString(`\"`)
+		case r == '\\':
+			quoted.WriteString(`\\`)
+		case r == '\n':
+			quoted.WriteString(`\n`)
+		case r == '\r':
+			quoted.WriteString(`\r`)
+		case r == '\t':
+			quoted.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[i+1:], "{"):
+			// Doubling the sign makes ${ and %{ literal
+			quoted.WriteRune(r)
+			quoted.WriteRune(r)
+		case r == utf8.RuneError:
+			quoted.WriteString(`\uFFFD`)
+		case !unicode.IsPrint(r) && r > 0xFFFF:
+			fmt.Fprintf(&quoted, `\U%08X`, r)
+		case !unicode.IsPrint(r):
+			fmt.Fprintf(&quoted, `\u%04X`, r)
+		default:
+			quoted.WriteRune(r)
+		}
+	}
+	quoted.WriteByte('"')
+	return quoted.String()
+}
+
+// invalidHCLNameChars matches characters that cannot appear in a Terraform resource name
+var invalidHCLNameChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// hclNamer hands out unique Terraform resource names per resource type
+type hclNamer map[string]bool
+
+// name derives a resource name such as tenant_admins from a Cognito name
+func (n hclNamer) name(resourceType string, value string) string {
+	name := strings.Trim(invalidHCLNameChars.ReplaceAllString(strings.ToLower(value), "_"), "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "r_" + name
+	}
+	unique := name
+	for i := 2; n[resourceType+"."+unique]; i++ {
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	n[resourceType+"."+unique] = true
+	return unique
+}
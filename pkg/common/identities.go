@@ -0,0 +1,40 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// LinkedIdentity is an external identity linked to a Cognito user, as stored in the
+// user's "identities" attribute
+type LinkedIdentity struct {
+	UserId       string `json:"userId"`       // Subject of the user at the identity provider
+	ProviderName string `json:"providerName"` // Name of the identity provider in the user pool
+	ProviderType string `json:"providerType"` // Google, Facebook, SAML, OIDC, ...
+	Primary      bool   `json:"primary"`      // Whether this identity was used to create the user
+	DateCreated  int64  `json:"dateCreated"`  // Unix time in milliseconds the link was created
+}
+
+// ParseIdentities reads the linked external identities from the attributes of a user
+// Parameters:
+//   - attributes: The attributes of the user as returned by AdminGetUser or ListUsers
+//
+// Returns:
+//   - []LinkedIdentity: The linked identities, empty when the user has none
+//   - error: Error if the identities attribute is not valid JSON
+func ParseIdentities(attributes []types.AttributeType) ([]LinkedIdentity, error) {
+	for _, attr := range attributes {
+		if aws.ToString(attr.Name) != "identities" {
+			continue
+		}
+		var identities []LinkedIdentity
+		if err := json.Unmarshal([]byte(aws.ToString(attr.Value)), &identities); err != nil {
+			return nil, fmt.Errorf("failed to parse identities attribute: %w", err)
+		}
+		return identities, nil
+	}
+	return nil, nil
+}
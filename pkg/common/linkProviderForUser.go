@@ -0,0 +1,53 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// LinkProviderForUser links an identity from an external provider to an existing native Cognito user,
+// so signing in through that provider signs in as the native user
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the native Cognito user
+//   - providerName: The name of the identity provider in the user pool, e.g. Google
+//   - providerAttributeName: The provider attribute that identifies the user, usually Cognito_Subject
+//   - providerAttributeValue: The value of that attribute for the user, e.g. their subject at the provider
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func LinkProviderForUser(userPoolId string, userName string, providerName string, providerAttributeName string, providerAttributeValue string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	input := &cognitoidentityprovider.AdminLinkProviderForUserInput{
+		UserPoolId: aws.String(userPoolId),
+		DestinationUser: &types.ProviderUserIdentifierType{
+			ProviderName:           aws.String("Cognito"),
+			ProviderAttributeValue: aws.String(userName),
+		},
+		SourceUser: &types.ProviderUserIdentifierType{
+			ProviderName:           aws.String(providerName),
+			ProviderAttributeName:  aws.String(providerAttributeName),
+			ProviderAttributeValue: aws.String(providerAttributeValue),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminLinkProviderForUser API
+	_, err := cogClient.AdminLinkProviderForUser(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to link %s identity %s to user %s: %w", providerName, providerAttributeValue, userName, err)
+	}
+
+	return nil
+}
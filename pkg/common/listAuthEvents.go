@@ -0,0 +1,58 @@
+package common
+
+import (
+	"context"
+	"iter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetAuthEvents retrieves the most recent authentication events of a user
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - userName: Username of the user
+//   - limit: Maximum number of events to return, 0 for all
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//
+// Returns:
+//   - []types.AuthEventType: The events, newest first
+//   - error: Error if the operation fails
+func GetAuthEvents(userPoolId string, userName string, limit int, awsConfig aws.Config, ctx context.Context) ([]types.AuthEventType, error) {
+	var events []types.AuthEventType
+	for event, err := range ListAuthEvents(userPoolId, userName, awsConfig, ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+		if limit > 0 && len(events) >= limit {
+			break
+		}
+	}
+	return events, nil
+}
+
+// ListAuthEvents streams the authentication events of a user page by page, newest first.
+// Events are only recorded when advanced security features are active on the pool.
+func ListAuthEvents(userPoolId string, userName string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.AuthEventType, error] {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// AdminListUserAuthEvents returns at most 60 events per page
+	var maxResults int32 = 60
+
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.AuthEventType, *string, error) {
+		output, err := cogClient.AdminListUserAuthEvents(ctx, &cognitoidentityprovider.AdminListUserAuthEventsInput{
+			UserPoolId: &userPoolId,
+			Username:   &userName,
+			MaxResults: &maxResults,
+			NextToken:  token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.AuthEvents, output.NextToken, nil
+	})
+}
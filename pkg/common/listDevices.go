@@ -0,0 +1,58 @@
+package common
+
+import (
+	"context"
+	"iter"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// GetDevices retrieves every device remembered for a user
+func GetDevices(userPoolId string, userName string, awsConfig aws.Config, ctx context.Context) ([]types.DeviceType, error) {
+	return collect(ListDevices(userPoolId, userName, awsConfig, ctx, nil), func(device types.DeviceType) types.DeviceType {
+		return device
+	})
+}
+
+// ListDevices streams the remembered devices of a user page by page
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - userName: Username of the user
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listing
+//   - progress: Optional callback invoked after every page
+//
+// Returns:
+//   - iter.Seq2[types.DeviceType, error]: The devices, or a single error that ends the stream
+func ListDevices(userPoolId string, userName string, awsConfig aws.Config, ctx context.Context, progress ProgressFunc) iter.Seq2[types.DeviceType, error] {
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// AdminListDevices returns at most 60 devices per page
+	var limit int32 = 60
+
+	return paginate(ctx, progress, func(ctx context.Context, token *string) ([]types.DeviceType, *string, error) {
+		output, err := cogClient.AdminListDevices(ctx, &cognitoidentityprovider.AdminListDevicesInput{
+			UserPoolId:      &userPoolId,
+			Username:        &userName,
+			Limit:           &limit,
+			PaginationToken: token,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return output.Devices, output.PaginationToken, nil
+	})
+}
+
+// DeviceAttribute returns the value of a named device attribute such as device_name or last_ip_used
+func DeviceAttribute(device types.DeviceType, name string) string {
+	for _, attr := range device.DeviceAttributes {
+		if aws.ToString(attr.Name) == name {
+			return aws.ToString(attr.Value)
+		}
+	}
+	return ""
+}
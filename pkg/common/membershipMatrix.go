@@ -0,0 +1,119 @@
+package common
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// MembershipMatrix records which users of a pool belong to which groups
+type MembershipMatrix struct {
+	Users   []string                   // Usernames, sorted
+	Groups  []string                   // Group names, sorted
+	members map[string]map[string]bool // Group name to the set of its members
+}
+
+// BuildMembershipMatrix lists every user and every group of a pool and the members of each group
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the listings
+//
+// Returns:
+//   - *MembershipMatrix: The users × groups membership matrix
+//   - error: Error if any of the listings fail
+func BuildMembershipMatrix(userPoolId string, awsConfig aws.Config, ctx context.Context) (*MembershipMatrix, error) {
+	users, err := GetUsersFromPool(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := GetGroupsFromPool(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	matrix := &MembershipMatrix{
+		Users:   users,
+		Groups:  groups,
+		members: make(map[string]map[string]bool),
+	}
+	sort.Strings(matrix.Users)
+	sort.Strings(matrix.Groups)
+
+	for _, group := range groups {
+		members, err := GetUsersInGroup(userPoolId, group, awsConfig, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members of group %s: %w", group, err)
+		}
+		matrix.members[group] = make(map[string]bool)
+		for _, member := range members {
+			matrix.members[group][member] = true
+		}
+	}
+
+	return matrix, nil
+}
+
+// IsMember reports whether the user belongs to the group
+func (m *MembershipMatrix) IsMember(user string, group string) bool {
+	return m.members[group][user]
+}
+
+// WriteCSV writes the matrix as CSV with one row per user and one column per group
+func (m *MembershipMatrix) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(append([]string{"username"}, m.Groups...)); err != nil {
+		return err
+	}
+	for _, user := range m.Users {
+		if err := writer.Write(m.row(user)); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// WriteMarkdown writes the matrix as a Markdown table with one row per user and one column per group
+func (m *MembershipMatrix) WriteMarkdown(w io.Writer) error {
+	header := append([]string{"Username"}, m.Groups...)
+	separator := make([]string, len(header))
+	separator[0] = "---"
+	for i := 1; i < len(separator); i++ {
+		separator[i] = ":---:"
+	}
+
+	lines := []string{markdownRow(header), markdownRow(separator)}
+	for _, user := range m.Users {
+		lines = append(lines, markdownRow(m.row(user)))
+	}
+	_, err := fmt.Fprintln(w, strings.Join(lines, "\n"))
+	return err
+}
+
+// row builds the cells of a user's row, marking memberships with an x
+func (m *MembershipMatrix) row(user string) []string {
+	cells := []string{user}
+	for _, group := range m.Groups {
+		if m.IsMember(user, group) {
+			cells = append(cells, "x")
+		} else {
+			cells = append(cells, "")
+		}
+	}
+	return cells
+}
+
+// markdownRow joins cells into a Markdown table row, escaping pipe characters
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
@@ -0,0 +1,77 @@
+package common
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/ramalabeysekera/cognito-user-management/pkg/helpers"
+)
+
+// PageTimeout bounds every single page request made while listing, so large
+// pools are not limited by one deadline shared across all pages
+const PageTimeout = 10 * time.Second
+
+// ProgressFunc is called after every page with the number of items fetched so far
+type ProgressFunc func(fetched int)
+
+// pageFetcher loads the page identified by token and returns its items
+// together with the token of the next page, nil when there are no more pages
+type pageFetcher[T any] func(ctx context.Context, token *string) ([]T, *string, error)
+
+// paginate turns a paged Cognito listing into a stream of items.
+// Pages are only requested while the caller keeps consuming the stream, every
+// page gets its own PageTimeout and cancelling ctx stops the listing with ctx's error.
+func paginate[T any](ctx context.Context, progress ProgressFunc, fetch pageFetcher[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		// Ctrl+C cancels ctx instead of exiting while the listing runs
+		defer helpers.TrackCancellable()()
+
+		var zero T
+		var token *string
+		fetched := 0
+
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+
+			pageCtx, cancel := context.WithTimeout(ctx, PageTimeout)
+			items, next, err := fetch(pageCtx, token)
+			cancel()
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			fetched += len(items)
+			if progress != nil {
+				progress(fetched)
+			}
+
+			if next == nil || *next == "" {
+				return
+			}
+			token = next
+		}
+	}
+}
+
+// collect drains a stream into a slice, converting every item with convert
+func collect[T any, R any](items iter.Seq2[T, error], convert func(T) R) ([]R, error) {
+	var result []R
+	for item, err := range items {
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, convert(item))
+	}
+	return result, nil
+}
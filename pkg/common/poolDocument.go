@@ -0,0 +1,379 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"gopkg.in/yaml.v3"
+)
+
+// PoolDocument describes a user pool and its groups, resource servers, identity providers and app clients.
+// The sections use the input shapes of the corresponding Create API calls, so the keys of the YAML
+// document match the Cognito API reference. User pool IDs are never part of a document.
+type PoolDocument struct {
+	Pool              cognitoidentityprovider.CreateUserPoolInput
+	Groups            []GroupDefinition
+	ResourceServers   []cognitoidentityprovider.CreateResourceServerInput
+	IdentityProviders []cognitoidentityprovider.CreateIdentityProviderInput
+	Clients           []cognitoidentityprovider.CreateUserPoolClientInput
+}
+
+// GroupDefinition is a group of a pool document
+type GroupDefinition struct {
+	GroupName string
+	GroupSettings
+}
+
+// documentKeyFields are fields that identify an entry of a document and are already part of its key when flattened
+var documentKeyFields = map[string]bool{
+	"UserPoolId":   true,
+	"Schema":       true,
+	"GroupName":    true,
+	"Identifier":   true,
+	"ProviderName": true,
+	"ClientName":   true,
+}
+
+// computedProviderDetails are provider details Cognito derives itself and rejects when they are sent back
+var computedProviderDetails = map[string]bool{
+	"attributes_url_add_attributes": true,
+	"ActiveEncryptionCertificate":   true,
+}
+
+// ExportPoolDocument reads the configuration of a user pool into a pool document.
+// Secrets of identity providers are replaced by ${VARIABLE} placeholders, see ResolveSecrets.
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the API calls
+//
+// Returns:
+//   - *PoolDocument: The configuration of the pool
+//   - error: Error if any of the API calls fail
+func ExportPoolDocument(userPoolId string, awsConfig aws.Config, ctx context.Context) (*PoolDocument, error) {
+	snapshot, err := BuildPoolSnapshot(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+	pool := snapshot.Pool
+
+	doc := &PoolDocument{
+		Pool: cognitoidentityprovider.CreateUserPoolInput{
+			PoolName:                    pool.Name,
+			AccountRecoverySetting:      pool.AccountRecoverySetting,
+			AdminCreateUserConfig:       pool.AdminCreateUserConfig,
+			AliasAttributes:             pool.AliasAttributes,
+			AutoVerifiedAttributes:      pool.AutoVerifiedAttributes,
+			DeletionProtection:          pool.DeletionProtection,
+			DeviceConfiguration:         pool.DeviceConfiguration,
+			EmailConfiguration:          pool.EmailConfiguration,
+			LambdaConfig:                pool.LambdaConfig,
+			MfaConfiguration:            pool.MfaConfiguration,
+			Policies:                    pool.Policies,
+			SmsAuthenticationMessage:    pool.SmsAuthenticationMessage,
+			SmsConfiguration:            pool.SmsConfiguration,
+			UserAttributeUpdateSettings: pool.UserAttributeUpdateSettings,
+			UserPoolAddOns:              pool.UserPoolAddOns,
+			UserPoolTags:                pool.UserPoolTags,
+			UserPoolTier:                pool.UserPoolTier,
+			UsernameAttributes:          pool.UsernameAttributes,
+			UsernameConfiguration:       pool.UsernameConfiguration,
+			VerificationMessageTemplate: pool.VerificationMessageTemplate,
+		},
+	}
+
+	// Standard attributes are only exported when they were made required, custom attributes always
+	for _, attr := range pool.SchemaAttributes {
+		name, custom := customAttributeName(aws.ToString(attr.Name))
+		if !custom && !aws.ToBool(attr.Required) {
+			continue
+		}
+		attr.Name = aws.String(name)
+		doc.Pool.Schema = append(doc.Pool.Schema, attr)
+	}
+
+	for _, group := range snapshot.Groups {
+		doc.Groups = append(doc.Groups, GroupDefinition{
+			GroupName: aws.ToString(group.GroupName),
+			GroupSettings: GroupSettings{
+				Description: aws.ToString(group.Description),
+				Precedence:  group.Precedence,
+				RoleArn:     aws.ToString(group.RoleArn),
+			},
+		})
+	}
+
+	servers, err := GetResourceServers(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resource servers of user pool %s: %w", userPoolId, err)
+	}
+	for _, server := range servers {
+		doc.ResourceServers = append(doc.ResourceServers, cognitoidentityprovider.CreateResourceServerInput{
+			Identifier: server.Identifier,
+			Name:       server.Name,
+			Scopes:     server.Scopes,
+		})
+	}
+	sort.Slice(doc.ResourceServers, func(i, j int) bool {
+		return aws.ToString(doc.ResourceServers[i].Identifier) < aws.ToString(doc.ResourceServers[j].Identifier)
+	})
+
+	providers, err := GetIdentityProviders(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list identity providers of user pool %s: %w", userPoolId, err)
+	}
+	sort.Strings(providers)
+	for _, name := range providers {
+		provider, err := DescribeIdentityProvider(userPoolId, name, awsConfig, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe identity provider %s: %w", name, err)
+		}
+		details := make(map[string]string)
+		for key, value := range provider.ProviderDetails {
+			switch {
+			case computedProviderDetails[key]:
+				// Left out, Cognito fills these in again
+			case strings.Contains(strings.ToLower(key), "secret"):
+				details[key] = "${" + secretVariable(name, key) + "}"
+			default:
+				details[key] = value
+			}
+		}
+		doc.IdentityProviders = append(doc.IdentityProviders, cognitoidentityprovider.CreateIdentityProviderInput{
+			ProviderName:     provider.ProviderName,
+			ProviderType:     provider.ProviderType,
+			ProviderDetails:  details,
+			AttributeMapping: provider.AttributeMapping,
+			IdpIdentifiers:   provider.IdpIdentifiers,
+		})
+	}
+
+	for _, client := range snapshot.Clients {
+		doc.Clients = append(doc.Clients, cognitoidentityprovider.CreateUserPoolClientInput{
+			ClientName:                               client.ClientName,
+			AccessTokenValidity:                      client.AccessTokenValidity,
+			AllowedOAuthFlows:                        client.AllowedOAuthFlows,
+			AllowedOAuthFlowsUserPoolClient:          aws.ToBool(client.AllowedOAuthFlowsUserPoolClient),
+			AllowedOAuthScopes:                       client.AllowedOAuthScopes,
+			AnalyticsConfiguration:                   client.AnalyticsConfiguration,
+			AuthSessionValidity:                      client.AuthSessionValidity,
+			CallbackURLs:                             client.CallbackURLs,
+			DefaultRedirectURI:                       client.DefaultRedirectURI,
+			EnablePropagateAdditionalUserContextData: client.EnablePropagateAdditionalUserContextData,
+			EnableTokenRevocation:                    client.EnableTokenRevocation,
+			ExplicitAuthFlows:                        client.ExplicitAuthFlows,
+			GenerateSecret:                           client.ClientSecret != nil,
+			IdTokenValidity:                          client.IdTokenValidity,
+			LogoutURLs:                               client.LogoutURLs,
+			PreventUserExistenceErrors:               client.PreventUserExistenceErrors,
+			ReadAttributes:                           client.ReadAttributes,
+			RefreshTokenValidity:                     client.RefreshTokenValidity,
+			SupportedIdentityProviders:               client.SupportedIdentityProviders,
+			TokenValidityUnits:                       client.TokenValidityUnits,
+			WriteAttributes:                          client.WriteAttributes,
+		})
+	}
+
+	return doc, nil
+}
+
+// ReadPoolDocument parses a YAML pool document
+// Returns an error if the document is not valid YAML, has unknown keys or does not name the pool
+func ReadPoolDocument(r io.Reader) (*PoolDocument, error) {
+	var tree any
+	if err := yaml.NewDecoder(r).Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to parse pool document: %w", err)
+	}
+
+	// Round-trip through JSON so the SDK types are filled by their field names
+	encoded, err := json.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pool document: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.DisallowUnknownFields()
+	doc := &PoolDocument{}
+	if err := decoder.Decode(doc); err != nil {
+		return nil, fmt.Errorf("failed to parse pool document: %w", err)
+	}
+
+	if aws.ToString(doc.Pool.PoolName) == "" {
+		return nil, fmt.Errorf("pool document has no Pool.PoolName")
+	}
+	return doc, nil
+}
+
+// WriteYAML writes the document as YAML, leaving out unset fields
+func (d *PoolDocument) WriteYAML(w io.Writer) error {
+	encoded, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to encode pool document: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var tree any
+	if err := decoder.Decode(&tree); err != nil {
+		return fmt.Errorf("failed to encode pool document: %w", err)
+	}
+
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(yamlTree(pruneTree(tree))); err != nil {
+		return fmt.Errorf("failed to write pool document: %w", err)
+	}
+	return encoder.Close()
+}
+
+// Flatten turns the document into key/value pairs such as "client[web].AllowedOAuthScopes"
+// Returns an error if any part of the document cannot be encoded
+func (d *PoolDocument) Flatten() (map[string]string, error) {
+	values := make(map[string]string)
+
+	if err := flattenInto(values, "pool", d.Pool, documentKeyFields); err != nil {
+		return nil, err
+	}
+	for _, attr := range d.Pool.Schema {
+		if err := flattenInto(values, fmt.Sprintf("schema[%s]", aws.ToString(attr.Name)), attr, documentKeyFields); err != nil {
+			return nil, err
+		}
+	}
+	for _, group := range d.Groups {
+		if err := flattenInto(values, fmt.Sprintf("group[%s]", group.GroupName), group, documentKeyFields); err != nil {
+			return nil, err
+		}
+	}
+	for _, server := range d.ResourceServers {
+		if err := flattenInto(values, fmt.Sprintf("resourceServer[%s]", aws.ToString(server.Identifier)), server, documentKeyFields); err != nil {
+			return nil, err
+		}
+	}
+	for _, provider := range d.IdentityProviders {
+		if err := flattenInto(values, fmt.Sprintf("identityProvider[%s]", aws.ToString(provider.ProviderName)), provider, documentKeyFields); err != nil {
+			return nil, err
+		}
+	}
+	for _, client := range d.Clients {
+		if err := flattenInto(values, fmt.Sprintf("client[%s]", aws.ToString(client.ClientName)), client, documentKeyFields); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// invalidVariableChars matches characters that cannot appear in an environment variable name
+var invalidVariableChars = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// secretReference matches a provider detail that consists of a single ${VARIABLE} placeholder
+var secretReference = regexp.MustCompile(`^\$\{([A-Za-z_][A-Za-z0-9_]*)\}$`)
+
+// ResolveSecrets replaces the ${VARIABLE} placeholders written by ExportPoolDocument in the provider details
+// of the identity providers. Only values that consist of a single placeholder are replaced, any other value
+// is kept as is, including one containing a literal "$".
+// Parameters:
+//   - lookup: Returns the value of a variable and whether it is set, e.g. os.LookupEnv
+//
+// Returns:
+//   - error: Error naming every variable that is not set
+func (d *PoolDocument) ResolveSecrets(lookup func(string) (string, bool)) error {
+	var missing []string
+	for _, provider := range d.IdentityProviders {
+		for key, value := range provider.ProviderDetails {
+			match := secretReference.FindStringSubmatch(value)
+			if match == nil {
+				continue
+			}
+			resolved, ok := lookup(match[1])
+			if !ok {
+				missing = append(missing, match[1])
+				continue
+			}
+			provider.ProviderDetails[key] = resolved
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// secretVariable derives the placeholder variable of a provider secret, e.g. IDP_GOOGLE_CLIENT_SECRET
+func secretVariable(providerName string, key string) string {
+	variable := strings.ToUpper("IDP_" + providerName + "_" + key)
+	return invalidVariableChars.ReplaceAllString(variable, "_")
+}
+
+// customAttributeName strips the custom: or dev:custom: prefix that Cognito adds to custom attributes
+// Returns the bare name and whether the attribute is a custom attribute
+func customAttributeName(name string) (string, bool) {
+	for _, prefix := range []string{"dev:custom:", "custom:"} {
+		if strings.HasPrefix(name, prefix) {
+			return strings.TrimPrefix(name, prefix), true
+		}
+	}
+	return name, false
+}
+
+// pruneTree removes nulls, empty strings, empty lists and empty maps from a decoded JSON tree
+func pruneTree(node any) any {
+	switch node := node.(type) {
+	case map[string]any:
+		for key, child := range node {
+			if pruned := pruneTree(child); pruned == nil {
+				delete(node, key)
+			} else {
+				node[key] = pruned
+			}
+		}
+		if len(node) == 0 {
+			return nil
+		}
+	case []any:
+		var kept []any
+		for _, child := range node {
+			if pruned := pruneTree(child); pruned != nil {
+				kept = append(kept, pruned)
+			}
+		}
+		if len(kept) == 0 {
+			return nil
+		}
+		return kept
+	case string:
+		if node == "" {
+			return nil
+		}
+	}
+	return node
+}
+
+// yamlTree converts JSON numbers to integers or floats so they are written as plain YAML numbers
+func yamlTree(node any) any {
+	switch node := node.(type) {
+	case map[string]any:
+		for key, child := range node {
+			node[key] = yamlTree(child)
+		}
+	case []any:
+		for i, child := range node {
+			node[i] = yamlTree(child)
+		}
+	case json.Number:
+		if value, err := node.Int64(); err == nil {
+			return value
+		}
+		if value, err := node.Float64(); err == nil {
+			return value
+		}
+	}
+	return node
+}
@@ -0,0 +1,162 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// PoolSnapshot holds the configuration of a user pool and the resources defined in it
+type PoolSnapshot struct {
+	Pool    *types.UserPoolType        // Pool settings, including the schema attributes
+	Clients []types.UserPoolClientType // App clients, sorted by name
+	Groups  []types.GroupType          // Groups, sorted by name
+}
+
+// snapshotIgnoredFields are top-level fields that name a resource or differ between any two pools and say nothing about their configuration
+var snapshotIgnoredFields = map[string]bool{
+	"Id":                     true,
+	"Arn":                    true,
+	"Name":                   true,
+	"ClientName":             true,
+	"GroupName":              true,
+	"UserPoolId":             true,
+	"ClientId":               true,
+	"ClientSecret":           true,
+	"CreationDate":           true,
+	"LastModifiedDate":       true,
+	"EstimatedNumberOfUsers": true,
+	"SchemaAttributes":       true,
+}
+
+// BuildPoolSnapshot fetches the settings, app clients and groups of a user pool
+// Parameters:
+//   - userPoolId: ID of the Cognito user pool
+//   - awsConfig: AWS configuration object
+//   - ctx: Context that cancels the API calls
+//
+// Returns:
+//   - *PoolSnapshot: The configuration of the pool
+//   - error: Error if any of the API calls fail
+func BuildPoolSnapshot(userPoolId string, awsConfig aws.Config, ctx context.Context) (*PoolSnapshot, error) {
+	pool, err := DescribeUserPool(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &PoolSnapshot{Pool: pool}
+
+	clients, err := GetPoolClients(userPoolId, awsConfig, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list app clients of user pool %s: %w", userPoolId, err)
+	}
+	for _, client := range clients {
+		details, err := DescribePoolClient(userPoolId, aws.ToString(client.ClientId), awsConfig, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe app client %s: %w", aws.ToString(client.ClientName), err)
+		}
+		snapshot.Clients = append(snapshot.Clients, *details)
+	}
+	sort.Slice(snapshot.Clients, func(i, j int) bool {
+		return aws.ToString(snapshot.Clients[i].ClientName) < aws.ToString(snapshot.Clients[j].ClientName)
+	})
+
+	snapshot.Groups, err = collect(ListGroups(userPoolId, awsConfig, ctx, nil), func(group types.GroupType) types.GroupType {
+		return group
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups of user pool %s: %w", userPoolId, err)
+	}
+	sort.Slice(snapshot.Groups, func(i, j int) bool {
+		return aws.ToString(snapshot.Groups[i].GroupName) < aws.ToString(snapshot.Groups[j].GroupName)
+	})
+
+	return snapshot, nil
+}
+
+// Flatten turns the snapshot into key/value pairs such as "client[web].AllowedOAuthScopes"
+// Identifiers, timestamps and user counts are left out so that snapshots of different pools can be compared.
+// Returns an error if any part of the snapshot cannot be encoded
+func (s *PoolSnapshot) Flatten() (map[string]string, error) {
+	values := make(map[string]string)
+
+	if err := flattenInto(values, "pool", s.Pool, snapshotIgnoredFields); err != nil {
+		return nil, err
+	}
+	for _, attr := range s.Pool.SchemaAttributes {
+		if err := flattenInto(values, fmt.Sprintf("schema[%s]", aws.ToString(attr.Name)), attr, snapshotIgnoredFields); err != nil {
+			return nil, err
+		}
+	}
+	for _, client := range s.Clients {
+		if err := flattenInto(values, fmt.Sprintf("client[%s]", aws.ToString(client.ClientName)), client, snapshotIgnoredFields); err != nil {
+			return nil, err
+		}
+	}
+	for _, group := range s.Groups {
+		if err := flattenInto(values, fmt.Sprintf("group[%s]", aws.ToString(group.GroupName)), group, snapshotIgnoredFields); err != nil {
+			return nil, err
+		}
+	}
+
+	return values, nil
+}
+
+// flattenInto encodes value as JSON and stores every leaf below prefix in values, skipping the ignored top-level fields
+func flattenInto(values map[string]string, prefix string, value any, ignored map[string]bool) error {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", prefix, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var tree any
+	if err := decoder.Decode(&tree); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", prefix, err)
+	}
+	if fields, ok := tree.(map[string]any); ok {
+		for field := range ignored {
+			delete(fields, field)
+		}
+	}
+	flattenTree(values, prefix, tree)
+	return nil
+}
+
+// flattenTree walks a decoded JSON tree. Lists of plain values are sorted and joined into one entry,
+// lists of objects get one entry per element.
+func flattenTree(values map[string]string, key string, node any) {
+	switch node := node.(type) {
+	case nil:
+		return
+	case string:
+		if node != "" {
+			values[key] = node
+		}
+	case map[string]any:
+		for field, child := range node {
+			flattenTree(values, key+"."+field, child)
+		}
+	case []any:
+		var scalars []string
+		for i, child := range node {
+			switch child.(type) {
+			case map[string]any, []any:
+				flattenTree(values, fmt.Sprintf("%s[%d]", key, i), child)
+			default:
+				scalars = append(scalars, fmt.Sprint(child))
+			}
+		}
+		if len(scalars) > 0 {
+			sort.Strings(scalars)
+			values[key] = strings.Join(scalars, ", ")
+		}
+	default:
+		values[key] = fmt.Sprint(node)
+	}
+}
@@ -0,0 +1,34 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+func RemoveUserFromGroup(userPoolId string, userName string, groupName string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Create the input for the AdminRemoveUserFromGroup API call
+	input := &cognitoidentityprovider.AdminRemoveUserFromGroupInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+		GroupName:  aws.String(groupName),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminRemoveUserFromGroup API
+	_, err := cogClient.AdminRemoveUserFromGroup(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to remove user %s from group %s: %w", userName, groupName, err)
+	}
+
+	return nil
+}
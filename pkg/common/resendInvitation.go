@@ -0,0 +1,40 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// ResendInvitation resends the invitation message to a user that has not signed in yet.
+// The user's temporary password is reset and the clock on its expiry starts again.
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the invited user
+//   - deliveryMediums: Channels the invitation is sent through
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func ResendInvitation(userPoolId string, userName string, deliveryMediums []types.DeliveryMediumType, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// AdminCreateUser with the RESEND action re-sends the invitation of an existing user
+	userInput := cognitoidentityprovider.AdminCreateUserInput{
+		UserPoolId:             &userPoolId,
+		Username:               &userName,
+		MessageAction:          types.MessageActionTypeResend,
+		DesiredDeliveryMediums: deliveryMediums,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err := cogClient.AdminCreateUser(ctx, &userInput)
+	return err
+}
@@ -0,0 +1,43 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+// ResetUserPassword resets a user's password so Cognito sends them a confirmation
+// code through their verified email or phone number. The user cannot sign in again
+// until they set a new password with that code.
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the user whose password is reset
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func ResetUserPassword(userPoolId string, userName string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Create the input for the AdminResetUserPassword API call
+	input := &cognitoidentityprovider.AdminResetUserPasswordInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminResetUserPassword API
+	_, err := cogClient.AdminResetUserPassword(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to reset password for user %s: %w", userName, err)
+	}
+
+	return nil
+}
@@ -28,6 +28,24 @@ type SetPermanentPasswordInput struct {
 //   - AdminSetUserPasswordOutput: Response from Cognito API
 //   - error: Any error that occurred during the operation
 func SetPermanentPassword(userPoolId string, username string, password string, AwsConfig aws.Config, ctx context.Context) (cognitoidentityprovider.AdminSetUserPasswordOutput, error) {
+	return SetUserPassword(userPoolId, username, password, true, AwsConfig, ctx)
+}
+
+// SetUserPassword sets either a permanent or a temporary password for a Cognito user.
+// A temporary password puts the user in FORCE_CHANGE_PASSWORD so they must choose
+// a new password at their next sign-in.
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - username: The username of the Cognito user
+//   - password: The new password to be set
+//   - permanent: Whether the password is permanent or must be changed at next login
+//   - AwsConfig: AWS configuration object
+//   - ctx: Context for the operation
+//
+// Returns:
+//   - AdminSetUserPasswordOutput: Response from Cognito API
+//   - error: Any error that occurred during the operation
+func SetUserPassword(userPoolId string, username string, password string, permanent bool, AwsConfig aws.Config, ctx context.Context) (cognitoidentityprovider.AdminSetUserPasswordOutput, error) {
 
 	// Initialize Cognito client with AWS configuration
 	cogClient := cognitoidentityprovider.NewFromConfig(AwsConfig)
@@ -37,12 +55,12 @@ func SetPermanentPassword(userPoolId string, username string, password string, A
 	defer cancel() // Ensure resources are cleaned up when function returns
 
 	// Prepare input for AdminSetUserPassword API call
-	// Setting Permanent to true makes this a permanent password change
+	// Permanent false forces the user to change the password at next login
 	adminSetPasswordInput := cognitoidentityprovider.AdminSetUserPasswordInput{
 		UserPoolId: &userPoolId,
 		Username:   &username,
 		Password:   &password,
-		Permanent:  true,
+		Permanent:  permanent,
 	}
 
 	// Call AdminSetUserPassword API to set the new password
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// MFAPreference describes the MFA factors to change for a user
+// A nil factor is left unchanged
+type MFAPreference struct {
+	SMS           *bool  // Enable or disable SMS MFA
+	SoftwareToken *bool  // Enable or disable TOTP (authenticator app) MFA
+	Email         *bool  // Enable or disable email MFA
+	Preferred     string // Factor to mark as preferred: SMS, SOFTWARE_TOKEN, EMAIL or empty
+}
+
+// SetUserMFAPreference enables, disables or prefers MFA factors for a user
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the user
+//   - preference: The factors to change
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func SetUserMFAPreference(userPoolId string, userName string, preference MFAPreference, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	input := &cognitoidentityprovider.AdminSetUserMFAPreferenceInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+	}
+	if preference.SMS != nil || preference.Preferred == "SMS" {
+		input.SMSMfaSettings = &types.SMSMfaSettingsType{
+			Enabled:      aws.ToBool(preference.SMS) || preference.Preferred == "SMS",
+			PreferredMfa: preference.Preferred == "SMS",
+		}
+	}
+	if preference.SoftwareToken != nil || preference.Preferred == "SOFTWARE_TOKEN" {
+		input.SoftwareTokenMfaSettings = &types.SoftwareTokenMfaSettingsType{
+			Enabled:      aws.ToBool(preference.SoftwareToken) || preference.Preferred == "SOFTWARE_TOKEN",
+			PreferredMfa: preference.Preferred == "SOFTWARE_TOKEN",
+		}
+	}
+	if preference.Email != nil || preference.Preferred == "EMAIL" {
+		input.EmailMfaSettings = &types.EmailMfaSettingsType{
+			Enabled:      aws.ToBool(preference.Email) || preference.Preferred == "EMAIL",
+			PreferredMfa: preference.Preferred == "EMAIL",
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminSetUserMFAPreference API
+	_, err := cogClient.AdminSetUserMFAPreference(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to set MFA preference of user %s: %w", userName, err)
+	}
+
+	return nil
+}
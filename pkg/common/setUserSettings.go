@@ -0,0 +1,44 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// ClearLegacyMFAOptions removes the legacy SMS MFA options of a user set through
+// AdminSetUserSettings, which AdminSetUserMFAPreference does not touch
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the user
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func ClearLegacyMFAOptions(userPoolId string, userName string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// An empty list of MFA options deactivates legacy SMS MFA
+	input := &cognitoidentityprovider.AdminSetUserSettingsInput{
+		UserPoolId: aws.String(userPoolId),
+		Username:   aws.String(userName),
+		MFAOptions: []types.MFAOptionType{},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminSetUserSettings API
+	_, err := cogClient.AdminSetUserSettings(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to clear MFA options of user %s: %w", userName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,321 @@
+package common
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+// PoolResourceIds identifies the live resources a pool document was exported from, so they can be imported
+type PoolResourceIds struct {
+	UserPoolId string            // ID of the user pool
+	ClientIds  map[string]string // App client name to client ID
+}
+
+// WriteTerraform writes the document as aws_cognito_* resources with import blocks for the live resources.
+// Identity provider secrets become sensitive input variables.
+// Parameters:
+//   - w: Writer that receives the HCL
+//   - ids: IDs of the live resources to import
+//
+// Returns:
+//   - error: Any error writing the configuration
+func (d *PoolDocument) WriteTerraform(w io.Writer, ids PoolResourceIds) error {
+	var blocks []*hclBlock
+	names := hclNamer{}
+	poolRef := hclExpr("aws_cognito_user_pool.pool.id")
+	imports := func(address string, id string) {
+		block := newHCLBlock("import")
+		block.set("to", hclExpr(address))
+		block.set("id", id)
+		blocks = append(blocks, block)
+	}
+
+	imports("aws_cognito_user_pool.pool", ids.UserPoolId)
+	blocks = append(blocks, terraformUserPool(d.Pool))
+
+	for _, group := range d.Groups {
+		name := names.name("aws_cognito_user_group", group.GroupName)
+		imports("aws_cognito_user_group."+name, ids.UserPoolId+"/"+group.GroupName)
+
+		block := newHCLBlock(fmt.Sprintf("resource %q %q", "aws_cognito_user_group", name))
+		block.set("user_pool_id", poolRef)
+		block.set("name", group.GroupName)
+		block.set("description", group.Description)
+		block.set("precedence", group.Precedence)
+		block.set("role_arn", group.RoleArn)
+		blocks = append(blocks, block)
+	}
+
+	for _, server := range d.ResourceServers {
+		identifier := aws.ToString(server.Identifier)
+		name := names.name("aws_cognito_resource_server", identifier)
+		imports("aws_cognito_resource_server."+name, ids.UserPoolId+"|"+identifier)
+
+		block := newHCLBlock(fmt.Sprintf("resource %q %q", "aws_cognito_resource_server", name))
+		block.set("user_pool_id", poolRef)
+		block.set("identifier", identifier)
+		block.set("name", server.Name)
+		for _, scope := range server.Scopes {
+			nested := block.block("scope")
+			nested.set("scope_name", scope.ScopeName)
+			nested.set("scope_description", scope.ScopeDescription)
+		}
+		blocks = append(blocks, block)
+	}
+
+	var variables []*hclBlock
+	for _, provider := range d.IdentityProviders {
+		providerName := aws.ToString(provider.ProviderName)
+		name := names.name("aws_cognito_identity_provider", providerName)
+		imports("aws_cognito_identity_provider."+name, ids.UserPoolId+":"+providerName)
+
+		details := make(map[string]hclExpr)
+		for key, value := range provider.ProviderDetails {
+			if match := secretReference.FindStringSubmatch(value); match != nil {
+				variable := strings.ToLower(match[1])
+				details[key] = hclExpr("var." + variable)
+
+				block := newHCLBlock(fmt.Sprintf("variable %q", variable))
+				block.set("type", hclExpr("string"))
+				block.set("sensitive", true)
+				variables = append(variables, block)
+				continue
+			}
+			details[key] = hclExpr(hclString(value))
+		}
+
+		block := newHCLBlock(fmt.Sprintf("resource %q %q", "aws_cognito_identity_provider", name))
+		block.set("user_pool_id", poolRef)
+		block.set("provider_name", providerName)
+		block.set("provider_type", string(provider.ProviderType))
+		block.set("provider_details", details)
+		block.set("attribute_mapping", provider.AttributeMapping)
+		block.set("idp_identifiers", provider.IdpIdentifiers)
+		blocks = append(blocks, block)
+	}
+
+	for _, client := range d.Clients {
+		clientName := aws.ToString(client.ClientName)
+		name := names.name("aws_cognito_user_pool_client", clientName)
+		if clientId, ok := ids.ClientIds[clientName]; ok {
+			imports("aws_cognito_user_pool_client."+name, ids.UserPoolId+"/"+clientId)
+		}
+		blocks = append(blocks, terraformUserPoolClient(name, client, poolRef))
+	}
+
+	// Check every block before writing so a failure does not leave a partial configuration
+	for _, block := range append(variables, blocks...) {
+		if err := block.validate(); err != nil {
+			return fmt.Errorf("failed to render Terraform configuration: %w", err)
+		}
+	}
+
+	fmt.Fprintf(w, "# Generated by cognitousermanagement from user pool %s\n", ids.UserPoolId)
+	fmt.Fprintln(w, "# Run terraform plan to review the imports, the plan should show no changes")
+	for _, block := range append(variables, blocks...) {
+		fmt.Fprintln(w)
+		block.write(w, 0)
+	}
+	return nil
+}
+
+// terraformUserPool converts the pool settings and schema to an aws_cognito_user_pool resource
+func terraformUserPool(pool cognitoidentityprovider.CreateUserPoolInput) *hclBlock {
+	block := newHCLBlock(fmt.Sprintf("resource %q %q", "aws_cognito_user_pool", "pool"))
+	block.set("name", pool.PoolName)
+	block.set("deletion_protection", string(pool.DeletionProtection))
+	block.set("user_pool_tier", string(pool.UserPoolTier))
+	block.set("mfa_configuration", string(pool.MfaConfiguration))
+	block.set("alias_attributes", enumValues(pool.AliasAttributes))
+	block.set("username_attributes", enumValues(pool.UsernameAttributes))
+	block.set("auto_verified_attributes", enumValues(pool.AutoVerifiedAttributes))
+	block.set("sms_authentication_message", pool.SmsAuthenticationMessage)
+	block.set("tags", pool.UserPoolTags)
+
+	if policies := pool.Policies; policies != nil {
+		if policy := policies.PasswordPolicy; policy != nil {
+			nested := block.block("password_policy")
+			nested.set("minimum_length", policy.MinimumLength)
+			nested.set("require_lowercase", policy.RequireLowercase)
+			nested.set("require_numbers", policy.RequireNumbers)
+			nested.set("require_symbols", policy.RequireSymbols)
+			nested.set("require_uppercase", policy.RequireUppercase)
+			nested.set("temporary_password_validity_days", policy.TemporaryPasswordValidityDays)
+			nested.set("password_history_size", policy.PasswordHistorySize)
+		}
+		if policy := policies.SignInPolicy; policy != nil {
+			block.block("sign_in_policy").set("allowed_first_auth_factors", enumValues(policy.AllowedFirstAuthFactors))
+		}
+	}
+
+	if config := pool.AdminCreateUserConfig; config != nil {
+		nested := block.block("admin_create_user_config")
+		nested.set("allow_admin_create_user_only", config.AllowAdminCreateUserOnly)
+		if template := config.InviteMessageTemplate; template != nil {
+			invite := nested.block("invite_message_template")
+			invite.set("email_message", template.EmailMessage)
+			invite.set("email_subject", template.EmailSubject)
+			invite.set("sms_message", template.SMSMessage)
+		}
+	}
+
+	if setting := pool.AccountRecoverySetting; setting != nil {
+		nested := block.block("account_recovery_setting")
+		for _, mechanism := range setting.RecoveryMechanisms {
+			recovery := nested.block("recovery_mechanism")
+			recovery.set("name", string(mechanism.Name))
+			recovery.set("priority", mechanism.Priority)
+		}
+	}
+
+	if config := pool.DeviceConfiguration; config != nil {
+		nested := block.block("device_configuration")
+		nested.set("challenge_required_on_new_device", config.ChallengeRequiredOnNewDevice)
+		nested.set("device_only_remembered_on_user_prompt", config.DeviceOnlyRememberedOnUserPrompt)
+	}
+
+	if config := pool.EmailConfiguration; config != nil {
+		nested := block.block("email_configuration")
+		nested.set("email_sending_account", string(config.EmailSendingAccount))
+		nested.set("from_email_address", config.From)
+		nested.set("reply_to_email_address", config.ReplyToEmailAddress)
+		nested.set("source_arn", config.SourceArn)
+		nested.set("configuration_set", config.ConfigurationSet)
+	}
+
+	if config := pool.SmsConfiguration; config != nil {
+		nested := block.block("sms_configuration")
+		nested.set("external_id", config.ExternalId)
+		nested.set("sns_caller_arn", config.SnsCallerArn)
+		nested.set("sns_region", config.SnsRegion)
+	}
+
+	if config := pool.LambdaConfig; config != nil {
+		nested := block.block("lambda_config")
+		nested.set("pre_sign_up", config.PreSignUp)
+		nested.set("custom_message", config.CustomMessage)
+		nested.set("post_confirmation", config.PostConfirmation)
+		nested.set("pre_authentication", config.PreAuthentication)
+		nested.set("post_authentication", config.PostAuthentication)
+		nested.set("define_auth_challenge", config.DefineAuthChallenge)
+		nested.set("create_auth_challenge", config.CreateAuthChallenge)
+		nested.set("verify_auth_challenge_response", config.VerifyAuthChallengeResponse)
+		nested.set("pre_token_generation", config.PreTokenGeneration)
+		nested.set("user_migration", config.UserMigration)
+		nested.set("kms_key_id", config.KMSKeyID)
+		if version := config.PreTokenGenerationConfig; version != nil {
+			sender := nested.block("pre_token_generation_config")
+			sender.set("lambda_arn", version.LambdaArn)
+			sender.set("lambda_version", string(version.LambdaVersion))
+		}
+		if version := config.CustomEmailSender; version != nil {
+			sender := nested.block("custom_email_sender")
+			sender.set("lambda_arn", version.LambdaArn)
+			sender.set("lambda_version", string(version.LambdaVersion))
+		}
+		if version := config.CustomSMSSender; version != nil {
+			sender := nested.block("custom_sms_sender")
+			sender.set("lambda_arn", version.LambdaArn)
+			sender.set("lambda_version", string(version.LambdaVersion))
+		}
+	}
+
+	if addOns := pool.UserPoolAddOns; addOns != nil {
+		block.block("user_pool_add_ons").set("advanced_security_mode", string(addOns.AdvancedSecurityMode))
+	}
+
+	if config := pool.UsernameConfiguration; config != nil {
+		block.block("username_configuration").set("case_sensitive", config.CaseSensitive)
+	}
+
+	if settings := pool.UserAttributeUpdateSettings; settings != nil {
+		block.block("user_attribute_update_settings").set("attributes_require_verification_before_update", enumValues(settings.AttributesRequireVerificationBeforeUpdate))
+	}
+
+	if template := pool.VerificationMessageTemplate; template != nil {
+		nested := block.block("verification_message_template")
+		nested.set("default_email_option", string(template.DefaultEmailOption))
+		nested.set("email_message", template.EmailMessage)
+		nested.set("email_message_by_link", template.EmailMessageByLink)
+		nested.set("email_subject", template.EmailSubject)
+		nested.set("email_subject_by_link", template.EmailSubjectByLink)
+		nested.set("sms_message", template.SmsMessage)
+	}
+
+	for _, attr := range pool.Schema {
+		nested := block.block("schema")
+		nested.set("name", attr.Name)
+		nested.set("attribute_data_type", string(attr.AttributeDataType))
+		nested.set("developer_only_attribute", attr.DeveloperOnlyAttribute)
+		nested.set("mutable", attr.Mutable)
+		nested.set("required", attr.Required)
+		if constraints := attr.StringAttributeConstraints; constraints != nil {
+			limits := nested.block("string_attribute_constraints")
+			limits.set("min_length", constraints.MinLength)
+			limits.set("max_length", constraints.MaxLength)
+		}
+		if constraints := attr.NumberAttributeConstraints; constraints != nil {
+			limits := nested.block("number_attribute_constraints")
+			limits.set("min_value", constraints.MinValue)
+			limits.set("max_value", constraints.MaxValue)
+		}
+	}
+
+	return block
+}
+
+// terraformUserPoolClient converts an app client to an aws_cognito_user_pool_client resource
+func terraformUserPoolClient(name string, client cognitoidentityprovider.CreateUserPoolClientInput, poolRef hclExpr) *hclBlock {
+	block := newHCLBlock(fmt.Sprintf("resource %q %q", "aws_cognito_user_pool_client", name))
+	block.set("user_pool_id", poolRef)
+	block.set("name", client.ClientName)
+	block.set("generate_secret", client.GenerateSecret)
+	block.set("access_token_validity", client.AccessTokenValidity)
+	block.set("id_token_validity", client.IdTokenValidity)
+	block.set("refresh_token_validity", client.RefreshTokenValidity)
+	block.set("auth_session_validity", client.AuthSessionValidity)
+	block.set("explicit_auth_flows", enumValues(client.ExplicitAuthFlows))
+	block.set("allowed_oauth_flows", enumValues(client.AllowedOAuthFlows))
+	block.set("allowed_oauth_flows_user_pool_client", client.AllowedOAuthFlowsUserPoolClient)
+	block.set("allowed_oauth_scopes", client.AllowedOAuthScopes)
+	block.set("callback_urls", client.CallbackURLs)
+	block.set("logout_urls", client.LogoutURLs)
+	block.set("default_redirect_uri", client.DefaultRedirectURI)
+	block.set("supported_identity_providers", client.SupportedIdentityProviders)
+	block.set("read_attributes", client.ReadAttributes)
+	block.set("write_attributes", client.WriteAttributes)
+	block.set("prevent_user_existence_errors", string(client.PreventUserExistenceErrors))
+	block.set("enable_token_revocation", client.EnableTokenRevocation)
+	block.set("enable_propagate_additional_user_context_data", client.EnablePropagateAdditionalUserContextData)
+
+	if units := client.TokenValidityUnits; units != nil {
+		nested := block.block("token_validity_units")
+		nested.set("access_token", string(units.AccessToken))
+		nested.set("id_token", string(units.IdToken))
+		nested.set("refresh_token", string(units.RefreshToken))
+	}
+
+	if analytics := client.AnalyticsConfiguration; analytics != nil {
+		nested := block.block("analytics_configuration")
+		nested.set("application_arn", analytics.ApplicationArn)
+		nested.set("application_id", analytics.ApplicationId)
+		nested.set("external_id", analytics.ExternalId)
+		nested.set("role_arn", analytics.RoleArn)
+		nested.set("user_data_shared", analytics.UserDataShared)
+	}
+
+	return block
+}
+
+// enumValues converts a slice of SDK enum values to plain strings
+func enumValues[T ~string](values []T) []string {
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = string(value)
+	}
+	return result
+}
@@ -0,0 +1,51 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// UpdateAuthEventFeedback tells Cognito whether an authentication event was legitimate,
+// which trains the risk model of advanced security features
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the user the event belongs to
+//   - eventId: The ID of the authentication event
+//   - valid: true if the sign-in was made by the user, false if it was not
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func UpdateAuthEventFeedback(userPoolId string, userName string, eventId string, valid bool, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	feedback := types.FeedbackValueTypeInvalid
+	if valid {
+		feedback = types.FeedbackValueTypeValid
+	}
+
+	input := &cognitoidentityprovider.AdminUpdateAuthEventFeedbackInput{
+		UserPoolId:    aws.String(userPoolId),
+		Username:      aws.String(userName),
+		EventId:       aws.String(eventId),
+		FeedbackValue: feedback,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminUpdateAuthEventFeedback API
+	_, err := cogClient.AdminUpdateAuthEventFeedback(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update feedback of event %s: %w", eventId, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// UpdateDeviceStatus marks a device of a user as remembered or not remembered
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the device owner
+//   - deviceKey: The key of the device
+//   - remembered: Whether the device should be remembered
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func UpdateDeviceStatus(userPoolId string, userName string, deviceKey string, remembered bool, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	status := types.DeviceRememberedStatusTypeNotRemembered
+	if remembered {
+		status = types.DeviceRememberedStatusTypeRemembered
+	}
+
+	input := &cognitoidentityprovider.AdminUpdateDeviceStatusInput{
+		UserPoolId:             aws.String(userPoolId),
+		Username:               aws.String(userName),
+		DeviceKey:              aws.String(deviceKey),
+		DeviceRememberedStatus: status,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminUpdateDeviceStatus API
+	_, err := cogClient.AdminUpdateDeviceStatus(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update status of device %s: %w", deviceKey, err)
+	}
+
+	return nil
+}
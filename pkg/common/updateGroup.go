@@ -0,0 +1,50 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+)
+
+// UpdateGroup replaces the description, precedence and IAM role of an existing group.
+// Cognito resets every setting that is not sent, so an empty RoleArn or nil Precedence removes
+// the current value. Start from the settings returned by GetGroup to keep them.
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - groupName: Name of the group to update
+//   - settings: The complete new settings of the group
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func UpdateGroup(userPoolId string, groupName string, settings GroupSettings, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Create the input for the UpdateGroup API call
+	input := &cognitoidentityprovider.UpdateGroupInput{
+		UserPoolId:  aws.String(userPoolId),
+		GroupName:   aws.String(groupName),
+		Description: aws.String(settings.Description),
+		Precedence:  settings.Precedence,
+	}
+	// An empty role ARN is rejected by Cognito, leaving it out removes the role instead
+	if settings.RoleArn != "" {
+		input.RoleArn = aws.String(settings.RoleArn)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the UpdateGroup API
+	_, err := cogClient.UpdateGroup(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update group %s: %w", groupName, err)
+	}
+
+	return nil
+}
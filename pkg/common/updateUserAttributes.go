@@ -0,0 +1,53 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// UpdateUserAttributes sets one or more attributes on a Cognito user
+// Parameters:
+//   - userPoolId: The ID of the Cognito user pool
+//   - userName: The username of the user to update
+//   - attributes: Attribute names mapped to their new values
+//   - awsConfig: AWS configuration object
+//
+// Returns:
+//   - error: Any error that occurred during the operation
+func UpdateUserAttributes(userPoolId string, userName string, attributes map[string]string, awsConfig aws.Config) error {
+
+	// Initialize Cognito client with AWS configuration
+	cogClient := cognitoidentityprovider.NewFromConfig(awsConfig)
+
+	// Convert the attribute map to the Cognito attribute type
+	var userAttributes []types.AttributeType
+	for name, value := range attributes {
+		userAttributes = append(userAttributes, types.AttributeType{
+			Name:  aws.String(name),
+			Value: aws.String(value),
+		})
+	}
+
+	// Create the input for the AdminUpdateUserAttributes API call
+	input := &cognitoidentityprovider.AdminUpdateUserAttributesInput{
+		UserPoolId:     aws.String(userPoolId),
+		Username:       aws.String(userName),
+		UserAttributes: userAttributes,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Call the AdminUpdateUserAttributes API
+	_, err := cogClient.AdminUpdateUserAttributes(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to update attributes of user %s: %w", userName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,198 @@
+package helpers
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// hibpPrefixLength is the number of hash characters that name a Have I Been Pwned range file
+const hibpPrefixLength = 5
+
+// BreachDatabase looks up passwords in a local copy of the Have I Been Pwned password corpus.
+// It is either a directory of range files, named after the first five characters of the SHA-1 hash
+// and holding SUFFIX:COUNT lines, or a single file of HASH:COUNT lines sorted by hash.
+type BreachDatabase struct {
+	path      string // directory of range files or sorted hash file
+	directory bool   // whether path is a directory of range files
+}
+
+// OpenBreachDatabase opens a local breach corpus
+// Parameters:
+//   - path: Directory of range files or a hash file sorted by hash
+//
+// Returns:
+//   - *BreachDatabase: The breach corpus
+//   - error: Error if the path does not exist
+func OpenBreachDatabase(path string) (*BreachDatabase, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breach database: %w", err)
+	}
+	return &BreachDatabase{path: path, directory: info.IsDir()}, nil
+}
+
+// Count returns how often a password appears in the breach corpus, 0 when it is not listed
+func (db *BreachDatabase) Count(password string) (int, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	if db.directory {
+		return db.countInRangeFile(hash)
+	}
+	return db.countInSortedFile(hash)
+}
+
+// countInRangeFile scans the range file of the hash prefix for the rest of the hash
+func (db *BreachDatabase) countInRangeFile(hash string) (int, error) {
+	prefix, suffix := hash[:hibpPrefixLength], hash[hibpPrefixLength:]
+
+	var f *os.File
+	var err error
+	for _, name := range []string{prefix, prefix + ".txt", strings.ToLower(prefix), strings.ToLower(prefix) + ".txt"} {
+		f, err = os.Open(filepath.Join(db.path, name))
+		if err == nil || !errors.Is(err, os.ErrNotExist) {
+			break
+		}
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		// No range file means no breached password shares the prefix
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to open breach range file: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key, count, ok := parseBreachLine(scanner.Text()); ok && key == suffix {
+			return count, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("failed to read breach range file: %w", err)
+	}
+	return 0, nil
+}
+
+// countInSortedFile binary searches a hash file sorted by hash. Lines have different lengths,
+// so every probe moves to the start of the next line before comparing.
+func (db *BreachDatabase) countInSortedFile(hash string) (int, error) {
+	f, err := os.Open(db.path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open breach database: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("failed to open breach database: %w", err)
+	}
+
+	// lo is always the start of a line, every line starting before lo sorts before hash
+	// and every line starting at or after hi sorts after it
+	lo, hi := int64(0), info.Size()
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		start := lo
+		if mid > lo {
+			start, err = nextLineStart(f, mid)
+			if err != nil {
+				return 0, err
+			}
+		}
+
+		// Blank lines hold no hash, compare against the next line that does
+		var line []byte
+		var key string
+		var count int
+		found := false
+		for start < hi {
+			line, err = readLineAt(f, start)
+			if err != nil {
+				return 0, err
+			}
+			if key, count, found = parseBreachLine(string(line)); found {
+				break
+			}
+			start += int64(len(line)) + 1
+		}
+		if !found {
+			// Only blank lines between mid and hi
+			hi = mid
+			continue
+		}
+
+		switch {
+		case key == hash:
+			return count, nil
+		case key < hash:
+			lo = start + int64(len(line)) + 1
+		default:
+			hi = start
+		}
+	}
+	return 0, nil
+}
+
+// nextLineStart returns the offset of the first line that starts at or after offset
+func nextLineStart(f *os.File, offset int64) (int64, error) {
+	buf := make([]byte, 256)
+	for pos := offset - 1; ; pos += int64(len(buf)) {
+		n, err := f.ReadAt(buf, pos)
+		if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+			return pos + int64(i) + 1, nil
+		}
+		if errors.Is(err, io.EOF) {
+			return pos + int64(n), nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read breach database: %w", err)
+		}
+	}
+}
+
+// readLineAt returns the line starting at offset without its line break
+func readLineAt(f *os.File, offset int64) ([]byte, error) {
+	var line []byte
+	buf := make([]byte, 256)
+	for pos := offset; ; pos += int64(len(buf)) {
+		n, err := f.ReadAt(buf, pos)
+		if i := bytes.IndexByte(buf[:n], '\n'); i >= 0 {
+			return append(line, buf[:i]...), nil
+		}
+		line = append(line, buf[:n]...)
+		if errors.Is(err, io.EOF) {
+			return line, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read breach database: %w", err)
+		}
+	}
+}
+
+// parseBreachLine splits a HASH:COUNT line into the upper case hash and the count.
+// Lines without a count are counted once.
+func parseBreachLine(line string) (string, int, bool) {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return "", 0, false
+	}
+	key, countText, found := strings.Cut(line, ":")
+	count := 1
+	if found {
+		if parsed, err := strconv.Atoi(strings.TrimSpace(countText)); err == nil {
+			count = parsed
+		}
+	}
+	return strings.ToUpper(strings.TrimSpace(key)), count, true
+}
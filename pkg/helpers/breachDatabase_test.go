@@ -0,0 +1,108 @@
+package helpers
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// sha1Hex returns the upper case SHA-1 hash of a password as listed in the breach corpus
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func TestBreachDatabaseSortedFile(t *testing.T) {
+	// SHA-1 hashes of these passwords sort as 3, 2, 4, 1, 5
+	first := sha1Hex("password3")
+	middle := sha1Hex("password4")
+	last := sha1Hex("password1")
+
+	tests := []struct {
+		name     string
+		contents string
+		password string
+		want     int
+	}{
+		{"first line", first + ":10\n" + middle + ":20\n" + last + ":30\n", "password3", 10},
+		{"middle line", first + ":10\n" + middle + ":20\n" + last + ":30\n", "password4", 20},
+		{"last line without trailing newline", first + ":10\n" + middle + ":20\n" + last + ":30", "password1", 30},
+		{"CRLF lines", first + ":10\r\n" + middle + ":20\r\n" + last + ":30\r\n", "password4", 20},
+		{"CRLF last line", first + ":10\r\n" + middle + ":20\r\n" + last + ":30\r\n", "password1", 30},
+		{"blank lines", "\n" + first + ":10\n\n\n" + middle + ":20\n\n" + last + ":30\n\n", "password3", 10},
+		{"blank lines before last", "\n" + first + ":10\n\n\n" + middle + ":20\n\n" + last + ":30\n\n", "password1", 30},
+		{"lowercase hashes", strings.ToLower(first) + ":10\n" + strings.ToLower(middle) + ":20\n", "password4", 20},
+		{"line without count", first + "\n" + middle + "\n", "password4", 1},
+		{"miss between two entries", first + ":10\n" + middle + ":20\n", "password2", 0},
+		{"miss before first entry", middle + ":20\n" + last + ":30\n", "password3", 0},
+		{"miss after last entry", first + ":10\n" + middle + ":20", "password1", 0},
+		{"one-line file", middle + ":20\n", "password4", 20},
+		{"one-line file without newline", middle + ":20", "password4", 20},
+		{"empty file", "", "password4", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pwned-passwords-sha1-ordered-by-hash.txt")
+			if err := os.WriteFile(path, []byte(tt.contents), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			db, err := OpenBreachDatabase(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := db.Count(tt.password)
+			if err != nil {
+				t.Fatalf("Count(%q) returned error: %v", tt.password, err)
+			}
+			if got != tt.want {
+				t.Errorf("Count(%q) = %d, want %d", tt.password, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBreachDatabaseRangeDirectory(t *testing.T) {
+	hash := sha1Hex("password1")
+	prefix, suffix := hash[:hibpPrefixLength], hash[hibpPrefixLength:]
+	other := strings.Repeat("0", len(suffix))
+
+	tests := []struct {
+		name     string
+		file     string
+		contents string
+		want     int
+	}{
+		{"prefix file", prefix, other + ":5\n" + suffix + ":42\n", 42},
+		{"txt file", prefix + ".txt", suffix + ":42\r\n", 42},
+		{"lowercase file", strings.ToLower(prefix), strings.ToLower(suffix) + ":42", 42},
+		{"lowercase txt file", strings.ToLower(prefix) + ".txt", "\n" + suffix + ":42\n\n", 42},
+		{"suffix not listed", prefix, other + ":5\n", 0},
+		{"no range file", "FFFFF", suffix + ":42\n", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, tt.file), []byte(tt.contents), 0600); err != nil {
+				t.Fatal(err)
+			}
+
+			db, err := OpenBreachDatabase(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := db.Count("password1")
+			if err != nil {
+				t.Fatalf("Count returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Count = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
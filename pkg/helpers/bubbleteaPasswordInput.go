@@ -0,0 +1,114 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+)
+
+type passwordInputModel struct {
+	prompt     string                    // question shown above the input
+	policy     *types.PasswordPolicyType // password policy the password must satisfy
+	password   []rune                    // password typed in the first step
+	confirm    []rune                    // password typed in the confirmation step
+	confirming bool                      // whether the confirmation step is active
+	message    string                    // error shown below the input
+	done       bool                      // whether a confirmed password was entered
+}
+
+func initialPasswordInputModel(prompt string, policy *types.PasswordPolicyType) passwordInputModel {
+	return passwordInputModel{
+		prompt: prompt,
+		policy: policy,
+	}
+}
+
+func (m passwordInputModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m passwordInputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	// Edit whichever step is active
+	input := &m.password
+	if m.confirming {
+		input = &m.confirm
+	}
+
+	switch key.Type {
+	case tea.KeyCtrlC, tea.KeyEsc:
+		return m, tea.Quit
+
+	case tea.KeyBackspace:
+		if len(*input) > 0 {
+			*input = (*input)[:len(*input)-1]
+		}
+
+	case tea.KeyCtrlU:
+		*input = nil
+
+	case tea.KeyRunes, tea.KeySpace:
+		*input = append(*input, key.Runes...)
+		m.message = ""
+
+	case tea.KeyEnter:
+		if !m.confirming {
+			if violations := ValidatePassword(string(m.password), m.policy); len(violations) > 0 {
+				m.message = "The password " + strings.Join(violations, ", ")
+				return m, nil
+			}
+			m.confirming = true
+			return m, nil
+		}
+		if string(m.confirm) != string(m.password) {
+			// Start over so a typo in the first entry can be fixed
+			m.password, m.confirm, m.confirming = nil, nil, false
+			m.message = "The passwords do not match, please enter them again"
+			return m, nil
+		}
+		m.done = true
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+func (m passwordInputModel) View() string {
+	if m.done {
+		return ""
+	}
+	var s strings.Builder
+
+	s.WriteString(m.prompt + "\n\n")
+	s.WriteString("Password: " + strings.Repeat("•", len(m.password)) + "\n")
+	if m.confirming {
+		s.WriteString("Confirm:  " + strings.Repeat("•", len(m.confirm)) + "\n")
+	}
+
+	// Strength meter of the first entry
+	score, label := PasswordStrength(string(m.password))
+	colors := []color.Attribute{color.FgRed, color.FgRed, color.FgYellow, color.FgGreen, color.FgGreen}
+	meter := color.New(colors[score]).Sprint(strings.Repeat("█", (score+1)*2))
+	s.WriteString(fmt.Sprintf("\nStrength: %s%s %s\n", meter, strings.Repeat("░", 10-(score+1)*2), label))
+
+	// Rules of the policy that are not met yet
+	if violations := ValidatePassword(string(m.password), m.policy); len(violations) > 0 && !m.confirming && m.message == "" {
+		s.WriteString("Missing:  " + strings.Join(violations, ", ") + "\n")
+	}
+	if m.message != "" {
+		s.WriteString("\n" + color.New(color.FgRed).Sprint(m.message) + "\n")
+	}
+
+	if m.confirming {
+		s.WriteString("\nType the password again and press enter to confirm, esc to cancel.\n")
+	} else {
+		s.WriteString("\nPress enter to continue, ctrl+u to clear, esc to cancel.\n")
+	}
+	return s.String()
+}
@@ -0,0 +1,137 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// maxColumnWidth caps the width of a table column, longer cells are truncated
+const maxColumnWidth = 32
+
+type tableModel struct {
+	headers []string   // column titles
+	rows    [][]string // cells of every row
+	widths  []int      // display width of every column
+	cursor  int        // index of the highlighted row
+	offset  int        // index of the first visible row
+	height  int        // number of visible rows
+	chosen  int        // row chosen with enter, -1 when the table was closed
+}
+
+func initialTableModel(headers []string, rows [][]string) tableModel {
+	widths := make([]int, len(headers))
+	for i, header := range headers {
+		widths[i] = len([]rune(header))
+	}
+	for _, row := range rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = min(max(widths[i], len([]rune(cell))), maxColumnWidth)
+			}
+		}
+	}
+
+	return tableModel{
+		headers: headers,
+		rows:    rows,
+		widths:  widths,
+		height:  15,
+		chosen:  -1,
+	}
+}
+
+func (m tableModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m tableModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// Leave room for the header, separator and help lines
+		m.height = max(msg.Height-6, 1)
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q", "esc":
+			return m, tea.Quit
+
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case "down", "j":
+			if m.cursor < len(m.rows)-1 {
+				m.cursor++
+			}
+
+		case "pgup":
+			m.cursor = max(m.cursor-m.height, 0)
+
+		case "pgdown":
+			m.cursor = max(min(m.cursor+m.height, len(m.rows)-1), 0)
+
+		case "home":
+			m.cursor = 0
+
+		case "end":
+			m.cursor = max(len(m.rows)-1, 0)
+
+		case "enter":
+			if len(m.rows) > 0 {
+				m.chosen = m.cursor
+				return m, tea.Quit
+			}
+		}
+	}
+
+	// Keep the cursor inside the visible window
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+m.height {
+		m.offset = m.cursor - m.height + 1
+	}
+	return m, nil
+}
+
+func (m tableModel) View() string {
+	var s strings.Builder
+
+	s.WriteString("  " + m.formatRow(m.headers) + "\n")
+	total := 0
+	for _, width := range m.widths {
+		total += width + 2
+	}
+	s.WriteString("  " + strings.Repeat("─", total) + "\n")
+
+	end := min(m.offset+m.height, len(m.rows))
+	for i := m.offset; i < end; i++ {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+		s.WriteString(cursor + " " + m.formatRow(m.rows[i]) + "\n")
+	}
+
+	s.WriteString(fmt.Sprintf("\nRow %d of %d. ↑/↓ to scroll, enter to select, q to quit.\n", min(m.cursor+1, len(m.rows)), len(m.rows)))
+	return s.String()
+}
+
+// formatRow pads or truncates every cell to its column width
+func (m tableModel) formatRow(cells []string) string {
+	parts := make([]string, len(m.widths))
+	for i, width := range m.widths {
+		var cell []rune
+		if i < len(cells) {
+			cell = []rune(cells[i])
+		}
+		if len(cell) > width {
+			cell = append(cell[:width-1], '…')
+		}
+		parts[i] = string(cell) + strings.Repeat(" ", width-len(cell))
+	}
+	return strings.Join(parts, "  ")
+}
@@ -4,18 +4,77 @@ import (
 	"bufio"
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 )
 
 // ReadUsersFromCsv reads user data from a CSV file and returns two slices containing usernames and temporary passwords
+// A header row starting with "username" is skipped
 // Parameters:
 //   userList: Slice to store usernames
 //   tempPasswordList: Slice to store temporary passwords
 // Returns:
 //   []string: Updated slice of usernames
 //   []string: Updated slice of temporary passwords
-func ReadUsersFromCsv(userList []string, tempPasswordList []string) ([]string, []string) {
+//   []int: Number of the row in the file of every user, counting the header
+func ReadUsersFromCsv(userList []string, tempPasswordList []string) ([]string, []string, []int) {
+
+	// Prompt user for input file path
+	filePath := PromptFilePath()
+
+	// Read all records from CSV file, expecting 2 fields per record
+	records, err := ReadCsvRecords(filePath, 2)
+	if err != nil {
+		PrintFatalErrorLog(err.Error())
+	}
+
+	// Process records by appending username and password to respective slices
+	var rowNumbers []int
+	for i, record := range records {
+		if i == 0 && IsCsvHeader(record) {
+			continue
+		}
+		userList = append(userList, record[0])                 // Add username from first column
+		tempPasswordList = append(tempPasswordList, record[1]) // Add password from second column
+		rowNumbers = append(rowNumbers, i+1)
+	}
+
+	return userList, tempPasswordList, rowNumbers
+}
+
+// ReadUsernamesFromCsv reads the usernames in the first column of a CSV file, any other columns are ignored
+// A header row starting with "username" is skipped
+func ReadUsernamesFromCsv() []string {
+
+	// Prompt user for input file path
+	filePath := PromptFilePath()
+
+	// Read all records from CSV file, allowing any number of fields per record
+	records, err := ReadCsvRecords(filePath, -1)
+	if err != nil {
+		PrintFatalErrorLog(err.Error())
+	}
+
+	var userList []string
+	for i, record := range records {
+		if i == 0 && IsCsvHeader(record) {
+			continue
+		}
+		userList = append(userList, record[0])
+	}
+
+	return userList
+}
+
+// IsCsvHeader reports whether a record is a header row, which starts with a "username" column
+func IsCsvHeader(record []string) bool {
+	return len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "username")
+}
+
+// PromptFilePath asks the user for the path of an input file
+// Surrounding quotes, as added by dragging a file into a terminal, are removed
+func PromptFilePath() string {
 
 	// Prompt user for input file path
 	fmt.Print("Please enter the file path to read from: ")
@@ -41,31 +100,40 @@ func ReadUsersFromCsv(userList []string, tempPasswordList []string) ([]string, [
 		filePath = strings.TrimSuffix(filePath, "\"")
 	}
 
-	// Open the CSV file
-	f, err := os.Open(filePath)
-	if err != nil {
-		PrintFatalErrorLog(fmt.Sprintf("Error opening file: %v", err))
-	}
+	return filePath
+}
 
-	// Ensure file is closed after function completes
-	defer f.Close()
+// ReadCsvRecords reads every record of a CSV file
+// Parameters:
+//   - filePath: Path of the CSV file, or "-" to read from stdin
+//   - fieldsPerRecord: Number of fields every record must have, or -1 for any
+//
+// Returns:
+//   - [][]string: The records of the file
+//   - error: Error if the file cannot be opened or parsed
+func ReadCsvRecords(filePath string, fieldsPerRecord int) ([][]string, error) {
+	var in io.Reader = os.Stdin
+	if filePath != "-" {
+		// Open the CSV file
+		f, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("error opening file: %w", err)
+		}
+
+		// Ensure file is closed after function completes
+		defer f.Close()
+		in = f
+	}
 
-	// Create CSV reader and configure it to expect 2 fields per record
-	r := csv.NewReader(f)
-	r.FieldsPerRecord = 2
+	// Create CSV reader and configure the expected number of fields per record
+	r := csv.NewReader(in)
+	r.FieldsPerRecord = fieldsPerRecord
 
 	// Read all records from CSV file
 	records, err := r.ReadAll()
-
 	if err != nil {
-		PrintFatalErrorLog(fmt.Sprintf("Error reading CSV: %v", err))
-	}
-
-	// Process records by appending username and password to respective slices
-	for _, record := range records {
-		userList = append(userList, record[0])                 // Add username from first column
-		tempPasswordList = append(tempPasswordList, record[1]) // Add password from second column
+		return nil, fmt.Errorf("error reading CSV: %w", err)
 	}
 
-	return userList, tempPasswordList
+	return records, nil
 }
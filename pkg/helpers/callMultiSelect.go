@@ -11,7 +11,7 @@ func CallMultiSelect(choices []string) ([]string) {
 	var result tea.Model
 	var err error
 
-	p := tea.NewProgram(initialModel(choices))
+	p := tea.NewProgram(initialModel(choices), tea.WithOutput(os.Stderr))
 	if result, err = p.Run(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
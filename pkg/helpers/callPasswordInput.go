@@ -0,0 +1,52 @@
+package helpers
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrPasswordCancelled is returned when password entry is cancelled
+var ErrPasswordCancelled = errors.New("password entry cancelled")
+
+// CallPasswordInput asks for a password twice without echoing it, showing its strength and the rules
+// of the password policy it does not meet yet. Only a password that satisfies the policy is accepted.
+// Returns the password, or ErrPasswordCancelled when entry was cancelled
+func CallPasswordInput(prompt string, policy *types.PasswordPolicyType) (string, error) {
+
+	var result tea.Model
+	var err error
+
+	p := tea.NewProgram(initialPasswordInputModel(prompt, policy), tea.WithOutput(os.Stderr))
+	if result, err = p.Run(); err != nil {
+		fmt.Printf("Alas, there's been an error: %v", err)
+		os.Exit(1)
+		return "", err
+	}
+
+	m := result.(passwordInputModel)
+	if !m.done {
+		return "", ErrPasswordCancelled
+	}
+	return string(m.password), nil
+}
+
+// ReadPasswordStdin reads a password from the first line of stdin, as piped from a secrets manager CLI
+// Returns an error if stdin is empty or cannot be read
+func ReadPasswordStdin() (string, error) {
+	password, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read password from stdin: %w", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		return "", errors.New("no password on stdin")
+	}
+	return password, nil
+}
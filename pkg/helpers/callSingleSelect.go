@@ -7,12 +7,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 )
 
+// CallSingleSelect lets the user pick one of the choices and returns it,
+// or an empty string when the list was closed without a choice
 func CallSingleSelect(choices []string) string {
 
 	var result tea.Model
 	var err error
 
-	p := tea.NewProgram(initialSingleSelectModel(choices))
+	p := tea.NewProgram(initialSingleSelectModel(choices), tea.WithOutput(os.Stderr))
 	if result, err = p.Run(); err != nil {
 		fmt.Printf("Alas, there's been an error: %v", err)
 		os.Exit(1)
@@ -22,9 +24,8 @@ func CallSingleSelect(choices []string) string {
 	// Fix: Convert to singleSelectModel instead of multiSelectModel
 	m := result.(singleSelectModel).selected
 
-	var selected []string
 	for i := range m {
-		selected = append(selected, choices[i])
+		return choices[i]
 	}
-	return selected[0]
+	return ""
 }
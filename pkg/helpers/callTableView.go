@@ -0,0 +1,25 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CallTableView shows rows in a scrollable table and returns the index of the
+// row chosen with enter, or -1 when the table was closed without a choice
+func CallTableView(headers []string, rows [][]string) int {
+
+	var result tea.Model
+	var err error
+
+	p := tea.NewProgram(initialTableModel(headers, rows), tea.WithOutput(os.Stderr))
+	if result, err = p.Run(); err != nil {
+		fmt.Printf("Alas, there's been an error: %v", err)
+		os.Exit(1)
+		return -1
+	}
+
+	return result.(tableModel).chosen
+}
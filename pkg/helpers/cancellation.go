@@ -0,0 +1,19 @@
+package helpers
+
+import "sync/atomic"
+
+// cancellable counts the running sections that check the command context and stop by themselves when it is cancelled
+var cancellable atomic.Int32
+
+// TrackCancellable marks the start of a section that stops cleanly once the command context is cancelled,
+// such as a paginated listing or a loop over users. The returned function marks its end.
+// While no such section runs, Ctrl+C exits right away because nothing would notice the cancellation.
+func TrackCancellable() func() {
+	cancellable.Add(1)
+	return func() { cancellable.Add(-1) }
+}
+
+// CancellableRunning reports whether a section that handles cancellation is running
+func CancellableRunning() bool {
+	return cancellable.Load() > 0
+}
@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+)
+
+// Confirm asks the user a yes/no question on stdin
+// Returns true only when the answer is "y"
+func Confirm(question string) bool {
+	fmt.Printf("%s (y/n): ", question)
+	reader := bufio.NewReader(os.Stdin)
+	confirmation, err := reader.ReadString('\n')
+	if err != nil {
+		log.Println("Error reading confirmation:", err)
+		return false
+	}
+	return strings.ToLower(strings.TrimSpace(confirmation)) == "y"
+}
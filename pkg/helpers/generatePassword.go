@@ -0,0 +1,143 @@
+package helpers
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+	"github.com/sethvargo/go-diceware/diceware"
+)
+
+// Character classes used for generated passwords. Spaces are left out of the symbols
+// so generated passwords survive copy and paste.
+const (
+	upperChars  = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	lowerChars  = "abcdefghijklmnopqrstuvwxyz"
+	digitChars  = "0123456789"
+	symbolChars = "^$*.[]{}()?\"!@#%&/\\,><':;|_~`=+-"
+)
+
+// DefaultPasswordLength is the length of generated passwords when the pool allows shorter ones
+const DefaultPasswordLength = 16
+
+// DefaultPassphraseWords is the number of words of generated passphrases
+const DefaultPassphraseWords = 5
+
+// GeneratePassword creates a cryptographically random password that satisfies the password policy.
+// The password always contains uppercase and lowercase letters, numbers and symbols.
+// Parameters:
+//   - policy: Password policy of the pool, nil for the Cognito defaults
+//   - length: Length of the password, 0 for DefaultPasswordLength. Raised to the minimum length of the policy.
+//
+// Returns:
+//   - string: The generated password
+//   - error: Error if the length is invalid, the password breaks the policy or the system random number generator fails
+func GeneratePassword(policy *types.PasswordPolicyType, length int) (string, error) {
+	if length == 0 {
+		length = DefaultPasswordLength
+	}
+	if length < 0 || length > MaximumPasswordLength {
+		return "", fmt.Errorf("invalid password length %d, expected 1 to %d", length, MaximumPasswordLength)
+	}
+	length = max(length, minimumPasswordLength(policy), 4)
+
+	// One character of every class, the rest from all classes
+	classes := []string{upperChars, lowerChars, digitChars, symbolChars}
+	all := strings.Join(classes, "")
+	password := make([]byte, 0, length)
+	for i := 0; i < length; i++ {
+		chars := all
+		if i < len(classes) {
+			chars = classes[i]
+		}
+		index, err := randomIndex(len(chars))
+		if err != nil {
+			return "", err
+		}
+		password = append(password, chars[index])
+	}
+
+	// Shuffle so the guaranteed characters are not always at the front
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := randomIndex(i + 1)
+		if err != nil {
+			return "", err
+		}
+		password[i], password[j] = password[j], password[i]
+	}
+
+	if violations := ValidatePassword(string(password), policy); len(violations) > 0 {
+		return "", fmt.Errorf("generated password %s", strings.Join(violations, ", "))
+	}
+	return string(password), nil
+}
+
+// GeneratePassphrase creates a diceware passphrase from the EFF large word list that satisfies the password policy.
+// Words are capitalized and joined with dashes, and a random digit is appended to one of them.
+// Words are added until the passphrase reaches the minimum length of the policy.
+// Parameters:
+//   - policy: Password policy of the pool, nil for the Cognito defaults
+//   - words: Number of words, 0 for DefaultPassphraseWords
+//
+// Returns:
+//   - string: The generated passphrase
+//   - error: Error if the number of words is invalid, the passphrase breaks the policy or the system random number generator fails
+func GeneratePassphrase(policy *types.PasswordPolicyType, words int) (string, error) {
+	if words == 0 {
+		words = DefaultPassphraseWords
+	}
+	if words < 0 {
+		return "", fmt.Errorf("invalid number of words %d, expected at least 1", words)
+	}
+
+	list, err := diceware.Generate(words)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate passphrase: %w", err)
+	}
+	for len(strings.Join(list, "-"))+1 < minimumPasswordLength(policy) {
+		extra, err := diceware.Generate(1)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate passphrase: %w", err)
+		}
+		list = append(list, extra...)
+	}
+
+	for i, word := range list {
+		list[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	word, err := randomIndex(len(list))
+	if err != nil {
+		return "", err
+	}
+	digit, err := randomIndex(len(digitChars))
+	if err != nil {
+		return "", err
+	}
+	list[word] += digitChars[digit : digit+1]
+
+	passphrase := strings.Join(list, "-")
+	if violations := ValidatePassword(passphrase, policy); len(violations) > 0 {
+		return "", fmt.Errorf("generated passphrase %s", strings.Join(violations, ", "))
+	}
+	return passphrase, nil
+}
+
+// minimumPasswordLength returns the minimum length the policy requires
+func minimumPasswordLength(policy *types.PasswordPolicyType) int {
+	if policy == nil || aws.ToInt32(policy.MinimumLength) == 0 {
+		return defaultMinimumPasswordLength
+	}
+	return int(aws.ToInt32(policy.MinimumLength))
+}
+
+// randomIndex returns a uniformly distributed random number in [0, n) from the system random number generator
+func randomIndex(n int) (int, error) {
+	index, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to read random numbers: %w", err)
+	}
+	return int(index.Int64()), nil
+}
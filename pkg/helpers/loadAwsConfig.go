@@ -4,6 +4,7 @@ package helpers
 import (
 	"context"
 	"fmt"
+	"os"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -37,10 +38,10 @@ func LoadAwsConfig() aws.Config {
 	}
 
 	// Print the profile being used
-	fmt.Println("Using profile:", profile)
+	fmt.Fprintln(os.Stderr, "Using profile:", profile)
 
 	// Print the configured AWS region
-	fmt.Println("Using region: ", cfg.Region)
+	fmt.Fprintln(os.Stderr, "Using region: ", cfg.Region)
 
 	// Create a new STS client using the loaded config
 	client := sts.NewFromConfig(cfg)
@@ -52,8 +53,31 @@ func LoadAwsConfig() aws.Config {
 	}
 
 	// Print account and identity information
-	fmt.Println("Using account ID:", *result.Account)
-	fmt.Println("Using caller identity:", *result.Arn)
+	fmt.Fprintln(os.Stderr, "Using account ID:", *result.Account)
+	fmt.Fprintln(os.Stderr, "Using caller identity:", *result.Arn)
 
 	return cfg
 }
+
+// LoadAwsConfigFor loads the AWS configuration of a named profile without prompting.
+// An empty region uses the region of the profile.
+// Returns an error when the configuration cannot be loaded or has no region.
+func LoadAwsConfigFor(profile string, region string) (aws.Config, error) {
+	var options []func(*config.LoadOptions) error
+	if profile != "" {
+		options = append(options, config.WithSharedConfigProfile(profile))
+	}
+	if region != "" {
+		options = append(options, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(context.TODO(), options...)
+	if err != nil {
+		return aws.Config{}, fmt.Errorf("failed to load AWS config for profile %q: %w", profile, err)
+	}
+	if cfg.Region == "" {
+		return aws.Config{}, fmt.Errorf("no region configured for profile %q", profile)
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,27 @@
+package helpers
+
+import (
+	zxcvbn "github.com/ccojocar/zxcvbn-go"
+)
+
+// strengthLabels names the zxcvbn scores from 0 to 4
+var strengthLabels = []string{"very weak", "weak", "fair", "strong", "very strong"}
+
+// strengthPrefixLength caps the characters zxcvbn looks at. Its matching slows down sharply on long inputs and
+// the meter runs on every key press. Extra characters only add strength, so the score of the prefix is a lower bound.
+const strengthPrefixLength = 48
+
+// PasswordStrength estimates the strength of a password with zxcvbn, which recognises dictionary words,
+// leet substitutions such as P@ssw0rd, keyboard patterns, sequences, repeats and dates.
+// Returns a score from 0 (very weak) to 4 (very strong) and a label for the score.
+func PasswordStrength(password string) (int, string) {
+	runes := []rune(password)
+	if len(runes) == 0 {
+		return 0, strengthLabels[0]
+	}
+	if len(runes) > strengthPrefixLength {
+		runes = runes[:strengthPrefixLength]
+	}
+	score := min(max(zxcvbn.PasswordStrength(string(runes), nil).Score, 0), len(strengthLabels)-1)
+	return score, strengthLabels[score]
+}
@@ -0,0 +1,82 @@
+package helpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// PrintDiff prints a colorized side by side comparison of two sets of key/value pairs.
+// Keys only on the left are red, keys only on the right green and keys whose values
+// differ yellow. Identical keys are only printed when onlyDifferences is false.
+// Returns the number of keys that differ.
+func PrintDiff(leftName string, rightName string, left map[string]string, right map[string]string, onlyDifferences bool) int {
+	red := color.New(color.FgRed).SprintFunc()
+	green := color.New(color.FgGreen).SprintFunc()
+	yellow := color.New(color.FgYellow).SprintFunc()
+
+	// Collect the keys of both sides in a stable order
+	keySet := make(map[string]struct{})
+	for key := range left {
+		keySet[key] = struct{}{}
+	}
+	for key := range right {
+		keySet[key] = struct{}{}
+	}
+	keys := make([]string, 0, len(keySet))
+	for key := range keySet {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Work out the column widths before any color codes are added
+	keyWidth, leftWidth := len("KEY"), len(leftName)
+	for _, key := range keys {
+		keyWidth = max(keyWidth, len(key))
+		leftWidth = max(leftWidth, len(diffCell(left, key)))
+	}
+	line := func(marker string, key string, l string, r string) string {
+		return fmt.Sprintf("%s %-*s  %-*s  %s", marker, keyWidth, key, leftWidth, l, r)
+	}
+
+	fmt.Println(line(" ", "KEY", leftName, rightName))
+	fmt.Println(strings.Repeat("─", keyWidth+leftWidth+len(rightName)+6))
+
+	differences := 0
+	for _, key := range keys {
+		l, inLeft := left[key]
+		r, inRight := right[key]
+		row := line(" ", key, diffCell(left, key), diffCell(right, key))
+		switch {
+		case inLeft && !inRight:
+			row = red(line("-", key, l, diffCell(right, key)))
+		case !inLeft && inRight:
+			row = green(line("+", key, diffCell(left, key), r))
+		case l != r:
+			row = yellow(line("~", key, l, r))
+		default:
+			if onlyDifferences {
+				continue
+			}
+			fmt.Println(row)
+			continue
+		}
+		differences++
+		fmt.Println(row)
+	}
+
+	if differences == 0 {
+		PrintSuccessLog("No differences found")
+	}
+	return differences
+}
+
+// diffCell returns the value of key, or a placeholder when the key is missing
+func diffCell(values map[string]string, key string) string {
+	if value, ok := values[key]; ok {
+		return value
+	}
+	return "(none)"
+}
@@ -0,0 +1,22 @@
+package helpers
+
+import (
+	"fmt"
+	"os"
+)
+
+// ProgressPrinter returns a progress callback that keeps a running count of
+// fetched items on a single stderr line, and a function that ends that line
+func ProgressPrinter(label string) (func(fetched int), func()) {
+	printed := false
+	update := func(fetched int) {
+		printed = true
+		fmt.Fprintf(os.Stderr, "\rFetching %s... %d", label, fetched)
+	}
+	done := func() {
+		if printed {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+	return update, done
+}
@@ -0,0 +1,19 @@
+package helpers
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// ReadInput prints a prompt and returns the trimmed line typed by the user
+func ReadInput(prompt string) string {
+	fmt.Print(prompt)
+	reader := bufio.NewReader(os.Stdin)
+	value, err := reader.ReadString('\n')
+	if err != nil && value == "" {
+		PrintFatalErrorLog(fmt.Sprintf("Error reading input: %v", err))
+	}
+	return strings.TrimSpace(value)
+}
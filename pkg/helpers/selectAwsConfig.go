@@ -3,6 +3,7 @@ package helpers
 import (
 	"fmt"
 	"log"
+	"os"
 )
 
 // SelectAwsProfile prompts user to select an AWS profile if multiple profiles exist
@@ -18,7 +19,7 @@ func SelectAwsProfile() string {
 
 	// If multiple profiles exist, show selection prompt
 	if len(profiles) > 1 {
-		fmt.Println("Found multiple AWS profiles, please choose which one to use!")
+		fmt.Fprintln(os.Stderr, "Found multiple AWS profiles, please choose which one to use!")
 		profile := CallSingleSelect(profiles)
 		return profile
 	} else {
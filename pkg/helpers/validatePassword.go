@@ -0,0 +1,69 @@
+package helpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/cognitoidentityprovider/types"
+)
+
+// PasswordSymbols are the characters Cognito accepts as symbols in a password
+const PasswordSymbols = "^$*.[]{}()?\"!@#%&/\\,><':;|_~`=+- "
+
+// defaultMinimumPasswordLength is the minimum length Cognito applies when a pool sets none
+const defaultMinimumPasswordLength = 8
+
+// MaximumPasswordLength is the longest password Cognito accepts
+const MaximumPasswordLength = 256
+
+// ValidatePassword checks a password against the password policy of a user pool, the same way Cognito does.
+// A nil policy applies the Cognito defaults: at least 8 characters of any kind.
+// Returns a description of every rule the password breaks, empty when it is valid.
+func ValidatePassword(password string, policy *types.PasswordPolicyType) []string {
+	if policy == nil {
+		policy = &types.PasswordPolicyType{}
+	}
+
+	var violations []string
+
+	minimumLength := minimumPasswordLength(policy)
+	length := len([]rune(password))
+	if length < minimumLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", minimumLength))
+	}
+	if length > MaximumPasswordLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d characters long", MaximumPasswordLength))
+	}
+	if password != strings.TrimSpace(password) {
+		violations = append(violations, "must not begin or end with a space")
+	}
+
+	// Cognito only counts letters and digits of the basic Latin alphabet
+	var upper, lower, number, symbol bool
+	for _, r := range password {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= '0' && r <= '9':
+			number = true
+		case strings.ContainsRune(PasswordSymbols, r):
+			symbol = true
+		}
+	}
+	if policy.RequireUppercase && !upper {
+		violations = append(violations, "must contain an uppercase letter")
+	}
+	if policy.RequireLowercase && !lower {
+		violations = append(violations, "must contain a lowercase letter")
+	}
+	if policy.RequireNumbers && !number {
+		violations = append(violations, "must contain a number")
+	}
+	if policy.RequireSymbols && !symbol {
+		violations = append(violations, fmt.Sprintf("must contain a symbol (%s)", strings.TrimSpace(PasswordSymbols)))
+	}
+
+	return violations
+}